@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// MaxRequestSize limits the JSON parameters of a single call
+const MaxRequestSize = 1 << 20
+
+type errorResponse struct {
+	Error string
+}
+
+type jobResponse struct {
+	ID     string
+	Method string
+	Done   bool
+	Last   *Event `json:",omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		slog.Warn("failed to write response", slog.Any("err", err))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func readParams(r *http.Request) (json.RawMessage, error) {
+	params, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	return params, nil
+}
+
+func jobStatus(job *Job) jobResponse {
+	last, done := job.Last()
+	return jobResponse{
+		ID:     job.ID,
+		Method: job.Method,
+		Done:   done,
+		Last:   last,
+	}
+}
+
+// NewHandler serves the methods over HTTP:
+//
+//	GET    /api/methods           lists the methods
+//	POST   /api/call/{method}     runs a method and responds with its result
+//	POST   /api/jobs/{method}     starts a method as a job and responds with its ID
+//	GET    /api/jobs/{id}         reports the state of a job
+//	GET    /api/jobs/{id}/events  streams the events of a job as Server-Sent Events
+//	DELETE /api/jobs/{id}         cancels a job
+func NewHandler(jobs *Jobs) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/methods", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, MethodInfos())
+	})
+
+	mux.HandleFunc("POST /api/call/{method}", func(w http.ResponseWriter, r *http.Request) {
+		m, ok := Methods[r.PathValue("method")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown method %q", r.PathValue("method")))
+			return
+		}
+
+		params, err := readParams(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		result, err := m.Call(r.Context(), params, nil)
+		switch {
+		case errors.Is(err, ErrInvalidParams):
+			writeError(w, http.StatusBadRequest, err)
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err)
+		default:
+			writeJSON(w, http.StatusOK, result)
+		}
+	})
+
+	mux.HandleFunc("POST /api/jobs/{method}", func(w http.ResponseWriter, r *http.Request) {
+		params, err := readParams(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		job, err := jobs.Start(r.PathValue("method"), params)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, jobStatus(job))
+	})
+
+	mux.HandleFunc("GET /api/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := jobs.Get(r.PathValue("id"))
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown job %q", r.PathValue("id")))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, jobStatus(job))
+	})
+
+	mux.HandleFunc("DELETE /api/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := jobs.Get(r.PathValue("id"))
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown job %q", r.PathValue("id")))
+			return
+		}
+
+		job.Cancel()
+		writeJSON(w, http.StatusOK, jobStatus(job))
+	})
+
+	mux.HandleFunc("GET /api/jobs/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := jobs.Get(r.PathValue("id"))
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown job %q", r.PathValue("id")))
+			return
+		}
+
+		streamEvents(w, r, job)
+	})
+
+	return mux
+}
+
+func streamEvents(w http.ResponseWriter, r *http.Request, job *Job) {
+	controller := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	sent := 0
+	for {
+		events, changed, done := job.Events(sent)
+		for _, event := range events {
+			payload, err := json.Marshal(event)
+			if err != nil {
+				slog.Warn("failed to encode event", slog.String("job", job.ID), slog.Any("err", err))
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+				return
+			}
+		}
+		sent += len(events)
+
+		if err := controller.Flush(); err != nil {
+			return
+		}
+
+		if done {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
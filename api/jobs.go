@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+type EventType string
+
+const (
+	ProgressEvent  = EventType("progress")
+	ResultEvent    = EventType("result")
+	ErrorEvent     = EventType("error")
+	CancelledEvent = EventType("cancelled")
+)
+
+type Event struct {
+	Type EventType
+	// Seed, JewelType and Conqueror describe the search reached by a progress event
+	Seed      uint32         `json:",omitempty"`
+	JewelType data.JewelType `json:",omitempty"`
+	Conqueror data.Conqueror `json:",omitempty"`
+	Result    any            `json:",omitempty"`
+	Error     string         `json:",omitempty"`
+}
+
+// Job is a method call running in the background, recording its events for any number of listeners
+type Job struct {
+	ID     string
+	Method string
+
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	events   []Event
+	done     bool
+	finished time.Time
+	changed  chan struct{}
+}
+
+func (j *Job) publish(event Event, done bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.events = append(j.events, event)
+	if done {
+		j.done = true
+		j.finished = time.Now()
+	}
+
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// Events returns the events from the given offset, a channel closed on the next event and whether the job is done
+func (j *Job) Events(from int) ([]Event, <-chan struct{}, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if from > len(j.events) {
+		from = len(j.events)
+	}
+
+	return append([]Event{}, j.events[from:]...), j.changed, j.done
+}
+
+// Last returns the latest event and whether the job is done
+func (j *Job) Last() (*Event, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if len(j.events) == 0 {
+		return nil, j.done
+	}
+
+	last := j.events[len(j.events)-1]
+	return &last, j.done
+}
+
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+func (j *Job) run(ctx context.Context, m Method, params json.RawMessage) {
+	defer j.cancel()
+
+	// A failing method fails its job rather than the whole server
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("job panicked", slog.String("job", j.ID), slog.String("method", j.Method), slog.Any("panic", r))
+			j.publish(Event{Type: ErrorEvent, Error: fmt.Sprintf("internal error: %v", r)}, true)
+		}
+	}()
+
+	result, err := m.Call(ctx, params, func(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) {
+		j.publish(Event{Type: ProgressEvent, Seed: seed, JewelType: jewelType, Conqueror: conqueror}, false)
+	})
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		j.publish(Event{Type: CancelledEvent, Error: err.Error()}, true)
+	case err != nil:
+		j.publish(Event{Type: ErrorEvent, Error: err.Error()}, true)
+	default:
+		j.publish(Event{Type: ResultEvent, Result: result}, true)
+	}
+}
+
+// DefaultRetention is how long finished jobs are kept for late listeners
+const DefaultRetention = 10 * time.Minute
+
+type Jobs struct {
+	Retention time.Duration
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+func NewJobs() *Jobs {
+	return &Jobs{
+		Retention: DefaultRetention,
+		jobs:      make(map[string]*Job),
+	}
+}
+
+func newJobID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Start runs a method in the background
+func (s *Jobs) Start(name string, params json.RawMessage) (*Job, error) {
+	m, ok := Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown method %q", name)
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &Job{
+		ID:      id,
+		Method:  name,
+		cancel:  cancel,
+		changed: make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.prune()
+	s.jobs[id] = job
+	s.mu.Unlock()
+
+	go job.run(ctx, m, params)
+
+	return job, nil
+}
+
+func (s *Jobs) Get(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+// prune drops jobs finished longer than Retention ago, the caller holds the lock
+func (s *Jobs) prune() {
+	for id, job := range s.jobs {
+		job.mu.Lock()
+		expired := job.done && time.Since(job.finished) > s.Retention
+		job.mu.Unlock()
+
+		if expired {
+			delete(s.jobs, id)
+		}
+	}
+}
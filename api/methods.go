@@ -0,0 +1,409 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/export"
+	"github.com/BlazesRus/timeless-jewels/item"
+)
+
+// ErrInvalidParams is wrapped by every error caused by the parameters of a call rather than by running it
+var ErrInvalidParams = errors.New("invalid params")
+
+// Method is a calculator function callable with JSON parameters
+type Method struct {
+	// Streaming methods are long running and report seed progress, they are meant to run as jobs
+	Streaming bool
+	Call      func(ctx context.Context, params json.RawMessage, updates calculator.JewelUpdateFunc) (any, error)
+}
+
+func method[P any](streaming bool, call func(ctx context.Context, params P, updates calculator.JewelUpdateFunc) (any, error)) Method {
+	return Method{
+		Streaming: streaming,
+		Call: func(ctx context.Context, raw json.RawMessage, updates calculator.JewelUpdateFunc) (any, error) {
+			var params P
+			if err := decodeParams(raw, &params); err != nil {
+				return nil, err
+			}
+			return call(ctx, params, updates)
+		},
+	}
+}
+
+// decodeParams accepts an object by field name, or an array in field order mirroring the WASM function arguments
+func decodeParams(raw json.RawMessage, params any) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	if raw[0] != '[' {
+		if err := json.Unmarshal(raw, params); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidParams, err)
+		}
+		return nil
+	}
+
+	var positional []json.RawMessage
+	if err := json.Unmarshal(raw, &positional); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+
+	value := reflect.ValueOf(params).Elem()
+	if len(positional) > value.NumField() {
+		return fmt.Errorf("%w: expected at most %d, got %d", ErrInvalidParams, value.NumField(), len(positional))
+	}
+
+	for i, param := range positional {
+		if err := json.Unmarshal(param, value.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidParams, value.Type().Field(i).Name, err)
+		}
+	}
+
+	return nil
+}
+
+// seedUpdates reports the progress of a single jewel search
+func seedUpdates(updates calculator.JewelUpdateFunc, timelessJewelType data.JewelType, conqueror data.Conqueror) calculator.UpdateFunc {
+	if updates == nil {
+		return nil
+	}
+
+	return func(seed uint32) {
+		updates(timelessJewelType, conqueror, seed)
+	}
+}
+
+type CalculateParams struct {
+	PassiveID uint32
+	Seed      uint32
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+}
+
+type SocketParams struct {
+	Socket    uint32
+	Seed      uint32
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+}
+
+type ReverseSearchParams struct {
+	PassiveIDs []uint32
+	StatIDs    []uint32
+	JewelType  data.JewelType
+	Conqueror  data.Conqueror
+}
+
+type PlanParams struct {
+	AllocatedGraphIDs []uint32
+	ClassID           int
+	Socket            uint32
+	Seed              uint32
+	JewelType         data.JewelType
+	Conqueror         data.Conqueror
+	Stats             []calculator.StatWeight
+	Budget            uint32
+}
+
+type CompareSocketParams struct {
+	Socket    uint32
+	Seed      uint32
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Language  data.Language
+}
+
+type RenderSocketParams struct {
+	Socket    uint32
+	Seed      uint32
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Language  data.Language
+	// Stats colour nodes by their weight
+	Stats []calculator.StatWeight
+	Width int
+}
+
+type DevotionParams struct {
+	PassiveIDs []uint32
+	Seed       uint32
+	Conqueror  data.Conqueror
+}
+
+type SearchDevotionParams struct {
+	PassiveIDs []uint32
+	Conqueror  data.Conqueror
+	Options    calculator.DevotionOptions
+}
+
+type FilterPassiveTypesParams struct {
+	PassiveIDs []uint32
+	Types      []data.PassiveSkillType
+}
+
+type CompareSeedsParams struct {
+	Socket   uint32
+	Jewels   []calculator.Jewel
+	Options  calculator.SearchOptions
+	Language data.Language
+}
+
+type SearchAllJewelsParams struct {
+	PassiveIDs []uint32
+	Options    calculator.SearchOptions
+}
+
+type SearchParams struct {
+	// PassiveIDs defaults to the passives in radius of Socket
+	PassiveIDs []uint32
+	Socket     uint32
+	JewelType  data.JewelType
+	Conqueror  data.Conqueror
+	Options    calculator.SearchOptions
+}
+
+// Constants mirrors the data the WASM build exposes besides its functions, so clients can build their pickers
+type Constants struct {
+	TimelessJewels          map[data.JewelType]string
+	PassiveSkillTypes       map[data.PassiveSkillType]string
+	TimelessJewelConquerors map[data.JewelType]map[data.Conqueror]*data.TimelessJewelConqueror
+	TimelessJewelSeedRanges map[data.JewelType]data.Range
+}
+
+type IndexParams struct {
+	Index uint32
+}
+
+type SearchStatsParams struct {
+	Query     string
+	JewelType data.JewelType
+	Language  data.Language
+}
+
+type TranslateParams struct {
+	Language  data.Language
+	StatIndex uint32
+	Roll      uint32
+}
+
+func (p SearchParams) passiveIDs() []uint32 {
+	if len(p.PassiveIDs) == 0 && p.Socket != 0 {
+		return calculator.SocketPassiveIDs(p.Socket)
+	}
+	return p.PassiveIDs
+}
+
+func validConqueror(jewelType data.JewelType, conqueror data.Conqueror) error {
+	if _, ok := data.TimelessJewelConquerors[jewelType][conqueror]; !ok {
+		return fmt.Errorf("%w: conqueror %q does not belong to jewel type %d", ErrInvalidParams, conqueror, jewelType)
+	}
+	return nil
+}
+
+func validJewel(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) error {
+	if err := item.Validate(jewelType, conqueror, seed); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+	return nil
+}
+
+// validPassives rejects passive indices that are unknown or that timeless jewels can not transform
+func validPassives(passiveIDs []uint32) error {
+	for _, id := range passiveIDs {
+		skill := data.GetPassiveSkillByIndex(id)
+		if skill == nil {
+			return fmt.Errorf("%w: unknown passive %d", ErrInvalidParams, id)
+		}
+		if !data.IsPassiveSkillValidForAlteration(skill) {
+			return fmt.Errorf("%w: passive %d can not be transformed", ErrInvalidParams, id)
+		}
+	}
+	return nil
+}
+
+func language(lang data.Language) data.Language {
+	if lang == "" {
+		return data.English
+	}
+	return lang
+}
+
+// Methods are named after the functions wasm/exposition exposes
+var Methods = map[string]Method{
+	"Constants": method(false, func(_ context.Context, _ struct{}, _ calculator.JewelUpdateFunc) (any, error) {
+		timelessJewels := make(map[data.JewelType]string, len(data.TimelessJewelConquerors))
+		for jewelType := range data.TimelessJewelConquerors {
+			timelessJewels[jewelType] = jewelType.String()
+		}
+
+		return Constants{
+			TimelessJewels: timelessJewels,
+			PassiveSkillTypes: map[data.PassiveSkillType]string{
+				data.SmallAttribute: "SmallAttribute",
+				data.SmallNormal:    "SmallNormal",
+				data.Notable:        "Notable",
+				data.KeyStone:       "KeyStone",
+			},
+			TimelessJewelConquerors: data.TimelessJewelConquerors,
+			TimelessJewelSeedRanges: data.TimelessJewelSeedRanges,
+		}, nil
+	}),
+	"Calculate": method(false, func(_ context.Context, p CalculateParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if err := validJewel(p.JewelType, p.Conqueror, p.Seed); err != nil {
+			return nil, err
+		}
+		if data.GetPassiveSkillByIndex(p.PassiveID) == nil {
+			return nil, fmt.Errorf("%w: unknown passive %d", ErrInvalidParams, p.PassiveID)
+		}
+		return calculator.Calculate(p.PassiveID, p.Seed, p.JewelType, p.Conqueror), nil
+	}),
+	"CalculateSocket": method(false, func(_ context.Context, p SocketParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if err := validJewel(p.JewelType, p.Conqueror, p.Seed); err != nil {
+			return nil, err
+		}
+		return calculator.CalculateSocket(p.Socket, p.Seed, p.JewelType, p.Conqueror), nil
+	}),
+	"ReverseSearch": method(true, func(ctx context.Context, p ReverseSearchParams, updates calculator.JewelUpdateFunc) (any, error) {
+		if err := validConqueror(p.JewelType, p.Conqueror); err != nil {
+			return nil, err
+		}
+		if err := validPassives(p.PassiveIDs); err != nil {
+			return nil, err
+		}
+		return calculator.ReverseSearchContext(ctx, p.PassiveIDs, p.StatIDs, p.JewelType, p.Conqueror, seedUpdates(updates, p.JewelType, p.Conqueror))
+	}),
+	"Search": method(true, func(ctx context.Context, p SearchParams, updates calculator.JewelUpdateFunc) (any, error) {
+		if err := validConqueror(p.JewelType, p.Conqueror); err != nil {
+			return nil, err
+		}
+		if err := validPassives(p.PassiveIDs); err != nil {
+			return nil, err
+		}
+		return calculator.SearchContext(ctx, p.passiveIDs(), p.JewelType, p.Conqueror, p.Options, seedUpdates(updates, p.JewelType, p.Conqueror))
+	}),
+	"GetStatByIndex": method(false, func(_ context.Context, p IndexParams, _ calculator.JewelUpdateFunc) (any, error) {
+		stat := data.GetStatByIndex(p.Index)
+		if stat == nil {
+			return nil, fmt.Errorf("%w: unknown stat %d", ErrInvalidParams, p.Index)
+		}
+		return stat, nil
+	}),
+	"SearchStats": method(false, func(_ context.Context, p SearchStatsParams, _ calculator.JewelUpdateFunc) (any, error) {
+		return data.SearchStats(p.Query, p.JewelType, language(p.Language)), nil
+	}),
+	"TranslateStat": method(false, func(_ context.Context, p TranslateParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if data.GetStatByIndex(p.StatIndex) == nil {
+			return nil, fmt.Errorf("%w: unknown stat %d", ErrInvalidParams, p.StatIndex)
+		}
+		return data.TranslateStat(language(p.Language), p.StatIndex, p.Roll), nil
+	}),
+	"GetAlternatePassiveSkillByIndex": method(false, func(_ context.Context, p IndexParams, _ calculator.JewelUpdateFunc) (any, error) {
+		return data.GetAlternatePassiveSkillByIndex(p.Index), nil
+	}),
+	"GetAlternatePassiveAdditionByIndex": method(false, func(_ context.Context, p IndexParams, _ calculator.JewelUpdateFunc) (any, error) {
+		return data.GetAlternatePassiveAdditionByIndex(p.Index), nil
+	}),
+	"GetPassiveSkillByIndex": method(false, func(_ context.Context, p IndexParams, _ calculator.JewelUpdateFunc) (any, error) {
+		return data.GetPassiveSkillByIndex(p.Index), nil
+	}),
+	"PlanAllocation": method(false, func(_ context.Context, p PlanParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if err := validJewel(p.JewelType, p.Conqueror, p.Seed); err != nil {
+			return nil, err
+		}
+
+		// The planner only fails on sockets out of reach of the class or the budget
+		plan, err := calculator.PlanAllocation(p.AllocatedGraphIDs, p.ClassID, p.Socket, p.Seed, p.JewelType, p.Conqueror, p.Stats, p.Budget)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidParams, err)
+		}
+		return plan, nil
+	}),
+	"CompareSocket": method(false, func(_ context.Context, p CompareSocketParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if err := validJewel(p.JewelType, p.Conqueror, p.Seed); err != nil {
+			return nil, err
+		}
+		return calculator.CompareSocket(p.Socket, p.Seed, p.JewelType, p.Conqueror, language(p.Language)), nil
+	}),
+	"RenderSocket": method(false, func(_ context.Context, p RenderSocketParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if err := validJewel(p.JewelType, p.Conqueror, p.Seed); err != nil {
+			return nil, err
+		}
+
+		options := export.SVGOptions{
+			Search:   calculator.SearchOptions{Stats: p.Stats},
+			Language: language(p.Language),
+			Width:    p.Width,
+		}
+
+		var svg strings.Builder
+		if err := export.WriteSocketSVG(&svg, p.Socket, p.JewelType, p.Conqueror, p.Seed, options); err != nil {
+			return nil, err
+		}
+		return svg.String(), nil
+	}),
+	"SummarizeDevotion": method(false, func(_ context.Context, p DevotionParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if err := validJewel(data.MilitantFaith, p.Conqueror, p.Seed); err != nil {
+			return nil, err
+		}
+		if err := validPassives(p.PassiveIDs); err != nil {
+			return nil, err
+		}
+		return calculator.SummarizeDevotion(p.PassiveIDs, p.Seed, p.Conqueror), nil
+	}),
+	"SearchDevotion": method(true, func(ctx context.Context, p SearchDevotionParams, updates calculator.JewelUpdateFunc) (any, error) {
+		if err := validConqueror(data.MilitantFaith, p.Conqueror); err != nil {
+			return nil, err
+		}
+		if err := validPassives(p.PassiveIDs); err != nil {
+			return nil, err
+		}
+		return calculator.SearchDevotionContext(ctx, p.PassiveIDs, p.Conqueror, p.Options, seedUpdates(updates, data.MilitantFaith, p.Conqueror))
+	}),
+	"FilterPassiveTypes": method(false, func(_ context.Context, p FilterPassiveTypesParams, _ calculator.JewelUpdateFunc) (any, error) {
+		return calculator.FilterPassiveTypes(p.PassiveIDs, p.Types), nil
+	}),
+	"CompareSeeds": method(false, func(_ context.Context, p CompareSeedsParams, _ calculator.JewelUpdateFunc) (any, error) {
+		if len(p.Jewels) < 2 {
+			return nil, fmt.Errorf("%w: need at least two jewels to compare, got %d", ErrInvalidParams, len(p.Jewels))
+		}
+		for _, jewel := range p.Jewels {
+			if err := validJewel(jewel.JewelType, jewel.Conqueror, jewel.Seed); err != nil {
+				return nil, err
+			}
+		}
+		return calculator.CompareSeeds(p.Socket, p.Jewels, p.Options, language(p.Language))
+	}),
+	"SearchAllJewels": method(true, func(ctx context.Context, p SearchAllJewelsParams, updates calculator.JewelUpdateFunc) (any, error) {
+		if err := validPassives(p.PassiveIDs); err != nil {
+			return nil, err
+		}
+		return calculator.SearchAllJewelsContext(ctx, p.PassiveIDs, p.Options, updates)
+	}),
+}
+
+// MethodInfo describes a method for clients discovering the API
+type MethodInfo struct {
+	Name      string
+	Streaming bool
+}
+
+func MethodInfos() []MethodInfo {
+	infos := make([]MethodInfo, 0, len(Methods))
+	for name, m := range Methods {
+		infos = append(infos, MethodInfo{Name: name, Streaming: m.Streaming})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// JSON-RPC 2.0 error codes, RequestCancelled follows the Language Server Protocol
+const (
+	ParseError       = -32700
+	InvalidRequest   = -32600
+	MethodNotFound   = -32601
+	InvalidParams    = -32602
+	InternalError    = -32603
+	RequestCancelled = -32800
+)
+
+const (
+	// ProgressNotification is sent by the server for every progress event of a request
+	ProgressNotification = "$/progress"
+	// CancelNotification is sent by the client to cancel a running request
+	CancelNotification = "$/cancelRequest"
+)
+
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+type ProgressParams struct {
+	ID        json.RawMessage `json:"id"`
+	Seed      uint32          `json:"seed"`
+	JewelType data.JewelType  `json:"jewelType"`
+	Conqueror data.Conqueror  `json:"conqueror"`
+}
+
+type CancelParams struct {
+	ID json.RawMessage `json:"id"`
+}
+
+// RPCServer runs methods as JSON-RPC 2.0 requests over newline delimited messages.
+// Requests run concurrently, so responses may arrive out of order.
+type RPCServer struct {
+	out   *json.Encoder
+	outMu sync.Mutex
+
+	running   map[string]context.CancelFunc
+	runningMu sync.Mutex
+
+	wg sync.WaitGroup
+}
+
+func NewRPCServer(out io.Writer) *RPCServer {
+	return &RPCServer{
+		out:     json.NewEncoder(out),
+		running: make(map[string]context.CancelFunc),
+	}
+}
+
+func (s *RPCServer) send(message rpcMessage) {
+	message.JSONRPC = "2.0"
+
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+
+	if err := s.out.Encode(message); err != nil {
+		// The client is gone, remaining requests are cancelled once input ends
+		return
+	}
+}
+
+func (s *RPCServer) reply(id json.RawMessage, result any, err *RPCError) {
+	if len(id) == 0 {
+		return
+	}
+
+	if err == nil && result == nil {
+		result = json.RawMessage("null")
+	}
+
+	s.send(rpcMessage{ID: id, Result: result, Error: err})
+}
+
+// Serve reads requests until the input ends or the context is done, then cancels and waits for running requests
+func (s *RPCServer) Serve(ctx context.Context, in io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.wg.Wait()
+	}()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxRequestSize)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var message rpcMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			s.reply(json.RawMessage("null"), nil, &RPCError{Code: ParseError, Message: err.Error()})
+			continue
+		}
+
+		s.handle(ctx, message)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read requests: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RPCServer) handle(ctx context.Context, message rpcMessage) {
+	if message.JSONRPC != "2.0" || message.Method == "" {
+		s.reply(message.ID, nil, &RPCError{Code: InvalidRequest, Message: "expected a JSON-RPC 2.0 request"})
+		return
+	}
+
+	if message.Method == CancelNotification {
+		var params CancelParams
+		if err := json.Unmarshal(message.Params, &params); err == nil {
+			s.cancel(params.ID)
+		}
+		return
+	}
+
+	m, ok := Methods[message.Method]
+	if !ok {
+		s.reply(message.ID, nil, &RPCError{Code: MethodNotFound, Message: fmt.Sprintf("unknown method %q", message.Method)})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	key := string(message.ID)
+	if len(message.ID) > 0 {
+		s.runningMu.Lock()
+		s.running[key] = cancel
+		s.runningMu.Unlock()
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			cancel()
+			s.runningMu.Lock()
+			delete(s.running, key)
+			s.runningMu.Unlock()
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				s.reply(message.ID, nil, &RPCError{Code: InternalError, Message: fmt.Sprintf("internal error: %v", r)})
+			}
+		}()
+
+		var updates func(data.JewelType, data.Conqueror, uint32)
+		if len(message.ID) > 0 {
+			updates = func(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) {
+				s.send(rpcMessage{
+					Method: ProgressNotification,
+					Params: mustMarshal(ProgressParams{ID: message.ID, Seed: seed, JewelType: jewelType, Conqueror: conqueror}),
+				})
+			}
+		}
+
+		result, err := m.Call(ctx, message.Params, updates)
+		switch {
+		case errors.Is(err, context.Canceled):
+			s.reply(message.ID, nil, &RPCError{Code: RequestCancelled, Message: err.Error()})
+		case errors.Is(err, ErrInvalidParams):
+			s.reply(message.ID, nil, &RPCError{Code: InvalidParams, Message: err.Error()})
+		case err != nil:
+			s.reply(message.ID, nil, &RPCError{Code: InternalError, Message: err.Error()})
+		default:
+			s.reply(message.ID, result, nil)
+		}
+	}()
+}
+
+func (s *RPCServer) cancel(id json.RawMessage) {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if cancel, ok := s.running[string(id)]; ok {
+		cancel()
+	}
+}
+
+func mustMarshal(value any) json.RawMessage {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
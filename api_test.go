@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/api"
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func postJSON(t *testing.T, url string, body string, value any) int {
+	t.Helper()
+
+	response, err := http.Post(url, "application/json", strings.NewReader(body))
+	testza.AssertNoError(t, err)
+	defer response.Body.Close()
+
+	testza.AssertNoError(t, json.NewDecoder(response.Body).Decode(value))
+	return response.StatusCode
+}
+
+func TestAPICall(t *testing.T) {
+	server := httptest.NewServer(api.NewHandler(api.NewJobs()))
+	defer server.Close()
+
+	var result data.AlternatePassiveSkillInformation
+	status := postJSON(t, server.URL+"/api/call/Calculate", `{"PassiveID":1210,"Seed":1001,"JewelType":1,"Conqueror":"Xibaqua"}`, &result)
+	testza.AssertEqual(t, http.StatusOK, status)
+	testza.AssertEqual(t, calculator.Calculate(1210, 1001, data.GloriousVanity, data.Xibaqua), result)
+
+	var text string
+	postJSON(t, server.URL+"/api/call/TranslateStat", `{"StatIndex":25,"Roll":12}`, &text)
+	testza.AssertEqual(t, "12% increased Spell Damage", text)
+
+	var failure map[string]string
+	status = postJSON(t, server.URL+"/api/call/Calculate", `{"PassiveID":1210,"Seed":1001,"JewelType":1,"Conqueror":"Kaom"}`, &failure)
+	testza.AssertEqual(t, http.StatusBadRequest, status)
+	testza.AssertNotEqual(t, "", failure["Error"])
+
+	status = postJSON(t, server.URL+"/api/call/CompareSeeds", `{"Socket":26725,"Jewels":[{"JewelType":1,"Conqueror":"Xibaqua","Seed":1001}]}`, &failure)
+	testza.AssertEqual(t, http.StatusBadRequest, status)
+
+	status = postJSON(t, server.URL+"/api/call/PlanAllocation", `{"ClassID":1,"Socket":26725,"Seed":1001,"JewelType":1,"Conqueror":"Xibaqua","Budget":1}`, &failure)
+	testza.AssertEqual(t, http.StatusBadRequest, status)
+
+	status = postJSON(t, server.URL+"/api/call/CalculateSocket", `{"Socket":26725,"Seed":1,"JewelType":1,"Conqueror":"Xibaqua"}`, &failure)
+	testza.AssertEqual(t, http.StatusBadRequest, status)
+
+	status = postJSON(t, server.URL+"/api/call/ReverseSearch", `{"PassiveIDs":[999999],"StatIDs":[25],"JewelType":1,"Conqueror":"Xibaqua"}`, &failure)
+	testza.AssertEqual(t, http.StatusBadRequest, status)
+}
+
+func readEvents(t *testing.T, url string) []api.Event {
+	t.Helper()
+
+	response, err := http.Get(url)
+	testza.AssertNoError(t, err)
+	defer response.Body.Close()
+
+	testza.AssertEqual(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	events := make([]api.Event, 0)
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 1<<16), 1<<26)
+	for scanner.Scan() {
+		if payload, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			var event api.Event
+			testza.AssertNoError(t, json.Unmarshal([]byte(payload), &event))
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+func TestAPIJobs(t *testing.T) {
+	server := httptest.NewServer(api.NewHandler(api.NewJobs()))
+	defer server.Close()
+
+	var job struct{ ID string }
+	status := postJSON(t, server.URL+"/api/jobs/ReverseSearch", `{"PassiveIDs":[1210],"StatIDs":[25],"JewelType":1,"Conqueror":"Xibaqua"}`, &job)
+	testza.AssertEqual(t, http.StatusAccepted, status)
+
+	events := readEvents(t, server.URL+"/api/jobs/"+job.ID+"/events")
+	testza.AssertGreater(t, len(events), 1)
+	testza.AssertEqual(t, api.ProgressEvent, events[0].Type)
+
+	last := events[len(events)-1]
+	testza.AssertEqual(t, api.ResultEvent, last.Type)
+	testza.AssertLen(t, last.Result, len(calculator.ReverseSearch([]uint32{1210}, []uint32{25}, data.GloriousVanity, data.Xibaqua, nil)))
+
+	postJSON(t, server.URL+"/api/jobs/Search", `{"Socket":26725,"JewelType":1,"Conqueror":"Doryani","Options":{"Stats":[{"ID":25,"Weight":1}]}}`, &job)
+
+	request, err := http.NewRequest(http.MethodDelete, server.URL+"/api/jobs/"+job.ID, nil)
+	testza.AssertNoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	testza.AssertNoError(t, err)
+	response.Body.Close()
+
+	events = readEvents(t, server.URL+"/api/jobs/"+job.ID+"/events")
+	testza.AssertEqual(t, api.CancelledEvent, events[len(events)-1].Type)
+
+	postJSON(t, server.URL+"/api/jobs/ReverseSearch", `{"PassiveIDs":[999999],"StatIDs":[25],"JewelType":1,"Conqueror":"Xibaqua"}`, &job)
+	events = readEvents(t, server.URL+"/api/jobs/"+job.ID+"/events")
+	testza.AssertEqual(t, api.ErrorEvent, events[len(events)-1].Type)
+}
@@ -0,0 +1,140 @@
+package calculator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+type StatLine struct {
+	Text string
+	// StatIndex is nil for lines taken from the skill tree text
+	StatIndex *uint32
+	Roll      uint32
+	// Lost marks original stats removed by a replacement
+	Lost bool
+	// Added marks stats granted by the jewel
+	Added bool
+}
+
+// NodeComparison holds the text of a passive before and after a jewel transforms it
+type NodeComparison struct {
+	Passive  uint32
+	GraphID  uint32
+	Name     string
+	NewName  string
+	Replaced bool
+	Before   []StatLine
+	After    []StatLine
+}
+
+// OriginalStats is Engine.OriginalStats on the default engine
+func OriginalStats(passiveSkill *data.PassiveSkill, lang data.Language) []StatLine {
+	return defaultEngine.OriginalStats(passiveSkill, lang)
+}
+
+// OriginalStats returns the text of an untransformed passive, from the skill tree or its stat templates
+func (e *Engine) OriginalStats(passiveSkill *data.PassiveSkill, lang data.Language) []StatLine {
+	dataset := e.Dataset()
+	lines := make([]StatLine, 0, len(passiveSkill.StatIndices))
+
+	if node, ok := dataset.SkillTree.Nodes[strconv.FormatUint(uint64(passiveSkill.PassiveSkillGraphID), 10)]; ok && len(node.Stats) > 0 && lang == data.English {
+		for _, stat := range node.Stats {
+			for _, line := range strings.Split(stat, "\n") {
+				lines = append(lines, StatLine{Text: line})
+			}
+		}
+		return lines
+	}
+
+	for _, statIndex := range passiveSkill.StatIndices {
+		lines = append(lines, StatLine{
+			Text:      dataset.TranslateStatTemplate(lang, statIndex),
+			StatIndex: &statIndex,
+		})
+	}
+
+	return lines
+}
+
+func rolledStats(dataset *data.Dataset, keys []uint32, rolls map[uint32]uint32, lang data.Language) []StatLine {
+	lines := make([]StatLine, 0, len(keys))
+	for i, key := range keys {
+		roll := rolls[uint32(i)]
+		lines = append(lines, StatLine{
+			Text:      dataset.TranslateStat(lang, key, roll),
+			StatIndex: &key,
+			Roll:      roll,
+			Added:     true,
+		})
+	}
+	return lines
+}
+
+// CompareNode is Engine.CompareNode on the default engine
+func CompareNode(passiveID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, lang data.Language) NodeComparison {
+	return defaultEngine.CompareNode(passiveID, seed, timelessJewelType, conqueror, lang)
+}
+
+// CompareNode describes how a seed changes a single passive, passives missing from the dataset give a zero NodeComparison
+func (e *Engine) CompareNode(passiveID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, lang data.Language) NodeComparison {
+	dataset := e.Dataset()
+	passiveSkill := dataset.GetPassiveSkillByIndex(passiveID)
+	if passiveSkill == nil {
+		return NodeComparison{}
+	}
+
+	result := e.Calculate(passiveID, seed, timelessJewelType, conqueror)
+
+	comparison := NodeComparison{
+		Passive:  passiveID,
+		GraphID:  passiveSkill.PassiveSkillGraphID,
+		Name:     passiveSkill.Name,
+		NewName:  passiveSkill.Name,
+		Replaced: result.AlternatePassiveSkill != nil,
+		Before:   e.OriginalStats(passiveSkill, lang),
+	}
+
+	if comparison.Replaced {
+		comparison.NewName = result.AlternatePassiveSkill.Name
+		for i := range comparison.Before {
+			comparison.Before[i].Lost = true
+		}
+		comparison.After = rolledStats(dataset, result.AlternatePassiveSkill.StatsKeys, result.StatRolls, lang)
+	} else {
+		comparison.After = append(comparison.After, comparison.Before...)
+	}
+
+	for _, augment := range result.AlternatePassiveAdditionInformations {
+		if augment.AlternatePassiveAddition == nil {
+			continue
+		}
+		comparison.After = append(comparison.After, rolledStats(dataset, augment.AlternatePassiveAddition.StatsKeys, augment.StatRolls, lang)...)
+	}
+
+	return comparison
+}
+
+// CompareSocket is Engine.CompareSocket on the default engine
+func CompareSocket(socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, lang data.Language) []NodeComparison {
+	return defaultEngine.CompareSocket(socketGraphID, seed, timelessJewelType, conqueror, lang)
+}
+
+// CompareSocket describes how a seed changes every passive in the radius of a socket, ordered by graph ID
+func (e *Engine) CompareSocket(socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, lang data.Language) []NodeComparison {
+	comparisons := make([]NodeComparison, 0)
+	for _, skill := range e.Tree().PassivesInRadius(socketGraphID) {
+		if !data.IsPassiveSkillValidForAlteration(skill) {
+			continue
+		}
+		comparisons = append(comparisons, e.CompareNode(skill.Index, seed, timelessJewelType, conqueror, lang))
+	}
+
+	sort.Slice(comparisons, func(i, j int) bool {
+		return comparisons[i].GraphID < comparisons[j].GraphID
+	})
+
+	return comparisons
+}
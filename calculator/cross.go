@@ -0,0 +1,120 @@
+package calculator
+
+import (
+	"context"
+	"sort"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+type JewelUpdateFunc func(timelessJewelType data.JewelType, conqueror data.Conqueror, seed uint32)
+
+// JewelScore is a seed score from a search across jewel types
+type JewelScore struct {
+	SeedScore
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	// Normalized is the total score relative to the best result of the search, from 0 to 1
+	Normalized float64
+}
+
+// SearchAllJewels is Engine.SearchAllJewels on the default engine
+func SearchAllJewels(passiveIDs []uint32, options SearchOptions, updates JewelUpdateFunc) []JewelScore {
+	return defaultEngine.SearchAllJewels(passiveIDs, options, updates)
+}
+
+// SearchAllJewelsContext is Engine.SearchAllJewelsContext on the default engine
+func SearchAllJewelsContext(ctx context.Context, passiveIDs []uint32, options SearchOptions, updates JewelUpdateFunc) ([]JewelScore, error) {
+	return defaultEngine.SearchAllJewelsContext(ctx, passiveIDs, options, updates)
+}
+
+// SearchAllJewels runs the same search for every jewel type and conqueror and merges the results into one ranking,
+// see RankJewelScores
+func (e *Engine) SearchAllJewels(passiveIDs []uint32, options SearchOptions, updates JewelUpdateFunc) []JewelScore {
+	scores, _ := e.SearchAllJewelsContext(context.Background(), passiveIDs, options, updates)
+	return scores
+}
+
+// SearchAllJewelsContext is SearchAllJewels stopping early once the context is done
+func (e *Engine) SearchAllJewelsContext(ctx context.Context, passiveIDs []uint32, options SearchOptions, updates JewelUpdateFunc) ([]JewelScore, error) {
+	jewelTypes := make([]data.JewelType, 0, len(data.TimelessJewelConquerors))
+	for jewelType := range data.TimelessJewelConquerors {
+		jewelTypes = append(jewelTypes, jewelType)
+	}
+	sort.Slice(jewelTypes, func(i, j int) bool {
+		return jewelTypes[i] < jewelTypes[j]
+	})
+
+	scores := make([]JewelScore, 0)
+	for _, jewelType := range jewelTypes {
+		conquerors := make([]data.Conqueror, 0, len(data.TimelessJewelConquerors[jewelType]))
+		for conqueror := range data.TimelessJewelConquerors[jewelType] {
+			conquerors = append(conquerors, conqueror)
+		}
+		sort.Slice(conquerors, func(i, j int) bool {
+			return conquerors[i] < conquerors[j]
+		})
+
+		for _, conqueror := range conquerors {
+			var seedUpdates UpdateFunc
+			if updates != nil {
+				seedUpdates = func(seed uint32) {
+					updates(jewelType, conqueror, seed)
+				}
+			}
+
+			cached := e.isCached(jewelType, conqueror)
+			results, err := e.SearchContext(ctx, passiveIDs, jewelType, conqueror, options, seedUpdates)
+
+			// Keep memory bounded to one jewel at a time rather than caching every combination
+			if !cached {
+				e.dropCache(jewelType, conqueror)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, score := range results {
+				scores = append(scores, JewelScore{
+					SeedScore: score,
+					JewelType: jewelType,
+					Conqueror: conqueror,
+				})
+			}
+		}
+	}
+
+	RankJewelScores(scores)
+
+	return scores, nil
+}
+
+// RankJewelScores orders scores of different jewels by total value, then jewel type, conqueror and seed,
+// and sets their Normalized score against the best total
+func RankJewelScores(scores []JewelScore) {
+	best := 0.0
+	for _, score := range scores {
+		best = max(best, score.Total())
+	}
+
+	for i := range scores {
+		scores[i].Normalized = 0
+		if best > 0 {
+			scores[i].Normalized = scores[i].Total() / best
+		}
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Total() != scores[j].Total() {
+			return scores[i].Total() > scores[j].Total()
+		}
+		if scores[i].JewelType != scores[j].JewelType {
+			return scores[i].JewelType < scores[j].JewelType
+		}
+		if scores[i].Conqueror != scores[j].Conqueror {
+			return scores[i].Conqueror < scores[j].Conqueror
+		}
+		return scores[i].Seed < scores[j].Seed
+	})
+}
@@ -0,0 +1,179 @@
+package calculator
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// DevotionStat is the base_devotion stat granted by Militant Faith replacements and additions
+const DevotionStat = uint32(9582)
+
+type TemplarNotable struct {
+	// Passive is the index of the replaced notable
+	Passive          uint32
+	AlternatePassive uint32
+	Name             string
+}
+
+type DevotionSummary struct {
+	Seed      uint32
+	Conqueror data.Conqueror
+	// Devotion is the total devotion from replacements and additions
+	Devotion        uint32
+	TemplarNotables []TemplarNotable
+}
+
+type DevotionOptions struct {
+	MinDevotion        uint32
+	MinTemplarNotables uint32
+	// Notables requires each of these alternate passive indices to be gained
+	Notables []uint32
+}
+
+// templarNotables maps the stats of Militant Faith notable replacements to their alternate passive
+func templarNotables(dataset *data.Dataset) map[uint32]*data.AlternatePassiveSkill {
+	notables := make(map[uint32]*data.AlternatePassiveSkill)
+	for _, skill := range dataset.AlternatePassiveSkills {
+		if skill.AlternateTreeVersionsKey != uint32(data.MilitantFaith) || !slices.Contains(skill.PassiveType, data.Notable) {
+			continue
+		}
+
+		for _, key := range skill.StatsKeys {
+			notables[key] = skill
+		}
+	}
+	return notables
+}
+
+func devotionStatIDs(notables map[uint32]*data.AlternatePassiveSkill) []uint32 {
+	ids := []uint32{DevotionStat}
+	for key := range notables {
+		ids = append(ids, key)
+	}
+	return ids
+}
+
+func summarizeDevotion(seed uint32, conqueror data.Conqueror, passives map[uint32]map[uint32]uint32, notables map[uint32]*data.AlternatePassiveSkill) DevotionSummary {
+	summary := DevotionSummary{
+		Seed:            seed,
+		Conqueror:       conqueror,
+		TemplarNotables: make([]TemplarNotable, 0),
+	}
+
+	for passiveID, stats := range passives {
+		summary.Devotion += stats[DevotionStat]
+
+		for key := range stats {
+			notable, ok := notables[key]
+			if !ok {
+				continue
+			}
+
+			summary.TemplarNotables = append(summary.TemplarNotables, TemplarNotable{
+				Passive:          passiveID,
+				AlternatePassive: notable.Index,
+				Name:             notable.Name,
+			})
+			break
+		}
+	}
+
+	sort.Slice(summary.TemplarNotables, func(i, j int) bool {
+		return summary.TemplarNotables[i].Passive < summary.TemplarNotables[j].Passive
+	})
+
+	return summary
+}
+
+// Matches applies the devotion, notable count and required notable filters
+func (o DevotionOptions) Matches(summary DevotionSummary) bool {
+	if summary.Devotion < o.MinDevotion || uint32(len(summary.TemplarNotables)) < o.MinTemplarNotables {
+		return false
+	}
+
+	for _, required := range o.Notables {
+		found := false
+		for _, notable := range summary.TemplarNotables {
+			found = found || notable.AlternatePassive == required
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SummarizeDevotion is Engine.SummarizeDevotion on the default engine
+func SummarizeDevotion(passiveIDs []uint32, seed uint32, conqueror data.Conqueror) DevotionSummary {
+	return defaultEngine.SummarizeDevotion(passiveIDs, seed, conqueror)
+}
+
+// SummarizeDevotion calculates the devotion summary of a Militant Faith seed over the given passives
+func (e *Engine) SummarizeDevotion(passiveIDs []uint32, seed uint32, conqueror data.Conqueror) DevotionSummary {
+	notables := templarNotables(e.Dataset())
+	statMap := make(map[uint32]bool)
+	for _, id := range devotionStatIDs(notables) {
+		statMap[id] = true
+	}
+
+	passives := make(map[uint32]map[uint32]uint32)
+	for _, passiveID := range passiveIDs {
+		result := e.Calculate(passiveID, seed, data.MilitantFaith, conqueror)
+		if stats := matchingStats(result, statMap); len(stats) > 0 {
+			passives[passiveID] = stats
+		}
+	}
+
+	return summarizeDevotion(seed, conqueror, passives, notables)
+}
+
+// SearchDevotion is Engine.SearchDevotion on the default engine
+func SearchDevotion(passiveIDs []uint32, conqueror data.Conqueror, options DevotionOptions, updates UpdateFunc) []DevotionSummary {
+	return defaultEngine.SearchDevotion(passiveIDs, conqueror, options, updates)
+}
+
+// SearchDevotionContext is Engine.SearchDevotionContext on the default engine
+func SearchDevotionContext(ctx context.Context, passiveIDs []uint32, conqueror data.Conqueror, options DevotionOptions, updates UpdateFunc) ([]DevotionSummary, error) {
+	return defaultEngine.SearchDevotionContext(ctx, passiveIDs, conqueror, options, updates)
+}
+
+// SearchDevotion summarizes every Militant Faith seed over the given passives,
+// dropping seeds that do not match and sorting the rest by devotion, then templar notables gained
+func (e *Engine) SearchDevotion(passiveIDs []uint32, conqueror data.Conqueror, options DevotionOptions, updates UpdateFunc) []DevotionSummary {
+	summaries, _ := e.SearchDevotionContext(context.Background(), passiveIDs, conqueror, options, updates)
+	return summaries
+}
+
+// SearchDevotionContext is SearchDevotion stopping early once the context is done
+func (e *Engine) SearchDevotionContext(ctx context.Context, passiveIDs []uint32, conqueror data.Conqueror, options DevotionOptions, updates UpdateFunc) ([]DevotionSummary, error) {
+	notables := templarNotables(e.Dataset())
+	results, err := e.ReverseSearchContext(ctx, passiveIDs, devotionStatIDs(notables), data.MilitantFaith, conqueror, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]DevotionSummary, 0, len(results))
+	for seed, passives := range results {
+		summary := summarizeDevotion(seed, conqueror, passives, notables)
+		if options.Matches(summary) {
+			summaries = append(summaries, summary)
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Devotion != summaries[j].Devotion {
+			return summaries[i].Devotion > summaries[j].Devotion
+		}
+		if len(summaries[i].TemplarNotables) != len(summaries[j].TemplarNotables) {
+			return len(summaries[i].TemplarNotables) > len(summaries[j].TemplarNotables)
+		}
+		return summaries[i].Seed < summaries[j].Seed
+	})
+
+	return summaries, nil
+}
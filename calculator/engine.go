@@ -0,0 +1,77 @@
+package calculator
+
+import (
+	"sync"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/random"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+// Engine calculates jewels against one dataset with its own result cache and random number generators.
+// Engines share no state, so one can be run per game version.
+type Engine struct {
+	dataset     *data.Dataset
+	passiveTree *tree.Tree
+
+	cacheMu sync.Mutex
+	cache   map[data.Conqueror]map[data.JewelType]*jewelCache
+	// cacheDataset is the dataset the cache was calculated from, the cache is dropped when data.SetDefault replaces it
+	cacheDataset *data.Dataset
+
+	rngs sync.Pool
+}
+
+// NewEngine creates an engine for a dataset, nil follows data.Default
+func NewEngine(dataset *data.Dataset) *Engine {
+	var passiveTree *tree.Tree
+	if dataset != nil {
+		passiveTree = tree.New(dataset)
+	}
+
+	return &Engine{
+		dataset:     dataset,
+		passiveTree: passiveTree,
+		cache:       make(map[data.Conqueror]map[data.JewelType]*jewelCache),
+		rngs: sync.Pool{
+			New: func() any {
+				return random.NewRNG()
+			},
+		},
+	}
+}
+
+var defaultEngine = NewEngine(nil)
+
+// DefaultEngine returns the engine used by the package level functions
+func DefaultEngine() *Engine {
+	return defaultEngine
+}
+
+// Dataset returns the game data the engine calculates with
+func (e *Engine) Dataset() *data.Dataset {
+	if e.dataset == nil {
+		return data.Default()
+	}
+	return e.dataset
+}
+
+// Tree returns the passive tree of the engine dataset
+func (e *Engine) Tree() *tree.Tree {
+	if e.passiveTree == nil {
+		return tree.Default()
+	}
+	return e.passiveTree
+}
+
+func (e *Engine) getRNG() *random.NumberGenerator {
+	return e.rngs.Get().(*random.NumberGenerator)
+}
+
+func (e *Engine) putRNG(rng *random.NumberGenerator) {
+	e.rngs.Put(rng)
+}
+
+func (e *Engine) treeManager() AlternateTreeManager {
+	return AlternateTreeManager{Dataset: e.Dataset()}
+}
@@ -1,22 +1,55 @@
 package calculator
 
 import (
+	"context"
+	"fmt"
+	"sync"
+
 	"github.com/BlazesRus/timeless-jewels/data"
-	"github.com/BlazesRus/timeless-jewels/random"
 )
 
 type UpdateFunc func(seed uint32)
 
-var calculationCache = make(map[data.Conqueror]map[data.JewelType]map[uint32]map[uint32]data.AlternatePassiveSkillInformation)
+// jewelCache holds the results of one jewel type and conqueror by seed and passive.
+// Searches lock it for their duration, so searches for different jewels run in parallel.
+type jewelCache struct {
+	mu    sync.Mutex
+	seeds map[uint32]map[uint32]data.AlternatePassiveSkillInformation
+}
+
+func (e *Engine) getJewelCache(timelessJewelType data.JewelType, conqueror data.Conqueror) *jewelCache {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	e.dropStaleCache()
+
+	if _, ok := e.cache[conqueror]; !ok {
+		e.cache[conqueror] = make(map[data.JewelType]*jewelCache)
+	}
+
+	if _, ok := e.cache[conqueror][timelessJewelType]; !ok {
+		e.cache[conqueror][timelessJewelType] = &jewelCache{
+			seeds: make(map[uint32]map[uint32]data.AlternatePassiveSkillInformation),
+		}
+	}
+
+	return e.cache[conqueror][timelessJewelType]
+}
 
+// Calculate is Engine.Calculate on the default engine
 func Calculate(passiveID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror) data.AlternatePassiveSkillInformation {
-	passiveSkill := data.GetPassiveSkillByIndex(passiveID)
+	return defaultEngine.Calculate(passiveID, seed, timelessJewelType, conqueror)
+}
+
+func (e *Engine) Calculate(passiveID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror) data.AlternatePassiveSkillInformation {
+	dataset := e.Dataset()
+	passiveSkill := dataset.GetPassiveSkillByIndex(passiveID)
 
 	if !data.IsPassiveSkillValidForAlteration(passiveSkill) {
 		return data.AlternatePassiveSkillInformation{}
 	}
 
-	alternateTreeVersion := data.GetAlternateTreeVersionIndex(uint32(timelessJewelType))
+	alternateTreeVersion := dataset.GetAlternateTreeVersionIndex(uint32(timelessJewelType))
 
 	timelessJewelConqueror := data.TimelessJewelConquerors[timelessJewelType][conqueror]
 
@@ -26,12 +59,13 @@ func Calculate(passiveID uint32, seed uint32, timelessJewelType data.JewelType,
 		TimelessJewelConqueror: timelessJewelConqueror,
 	}
 
-	alternateTreeManager := AlternateTreeManager{
-		PassiveSkill:  passiveSkill,
-		TimelessJewel: timelessJewel,
-	}
+	alternateTreeManager := e.treeManager()
+	alternateTreeManager.PassiveSkill = passiveSkill
+	alternateTreeManager.TimelessJewel = timelessJewel
+
+	rng := e.getRNG()
+	defer e.putRNG(rng)
 
-	rng := random.NewRNG()
 	if alternateTreeManager.IsPassiveSkillReplaced(rng) {
 		return alternateTreeManager.ReplacePassiveSkill(rng)
 	}
@@ -41,16 +75,34 @@ func Calculate(passiveID uint32, seed uint32, timelessJewelType data.JewelType,
 	}
 }
 
+// ReverseSearch is Engine.ReverseSearch on the default engine
 func ReverseSearch(passiveIDs []uint32, statIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, updates UpdateFunc) map[uint32]map[uint32]map[uint32]uint32 {
+	return defaultEngine.ReverseSearch(passiveIDs, statIDs, timelessJewelType, conqueror, updates)
+}
+
+// ReverseSearchContext is Engine.ReverseSearchContext on the default engine
+func ReverseSearchContext(ctx context.Context, passiveIDs []uint32, statIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, updates UpdateFunc) (map[uint32]map[uint32]map[uint32]uint32, error) {
+	return defaultEngine.ReverseSearchContext(ctx, passiveIDs, statIDs, timelessJewelType, conqueror, updates)
+}
+
+func (e *Engine) ReverseSearch(passiveIDs []uint32, statIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, updates UpdateFunc) map[uint32]map[uint32]map[uint32]uint32 {
+	results, _ := e.ReverseSearchContext(context.Background(), passiveIDs, statIDs, timelessJewelType, conqueror, updates)
+	return results
+}
+
+// ReverseSearchContext is ReverseSearch stopping early once the context is done
+func (e *Engine) ReverseSearchContext(ctx context.Context, passiveIDs []uint32, statIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, updates UpdateFunc) (map[uint32]map[uint32]map[uint32]uint32, error) {
+	dataset := e.Dataset()
+
 	passiveSkills := make(map[uint32]*data.PassiveSkill)
 	for _, id := range passiveIDs {
-		skill := data.GetPassiveSkillByIndex(id)
+		skill := dataset.GetPassiveSkillByIndex(id)
 		if data.IsPassiveSkillValidForAlteration(skill) {
 			passiveSkills[id] = skill
 		}
 	}
 
-	alternateTreeVersion := data.GetAlternateTreeVersionIndex(uint32(timelessJewelType))
+	alternateTreeVersion := dataset.GetAlternateTreeVersionIndex(uint32(timelessJewelType))
 
 	timelessJewelConqueror := data.TimelessJewelConquerors[timelessJewelType][conqueror]
 
@@ -59,20 +111,16 @@ func ReverseSearch(passiveIDs []uint32, statIDs []uint32, timelessJewelType data
 		TimelessJewelConqueror: timelessJewelConqueror,
 	}
 
-	alternateTreeManager := AlternateTreeManager{}
+	alternateTreeManager := e.treeManager()
 
 	statMap := make(map[uint32]bool)
 	for _, id := range statIDs {
 		statMap[id] = true
 	}
 
-	if _, ok := calculationCache[conqueror]; !ok {
-		calculationCache[conqueror] = make(map[data.JewelType]map[uint32]map[uint32]data.AlternatePassiveSkillInformation)
-	}
-
-	if _, ok := calculationCache[conqueror][timelessJewelType]; !ok {
-		calculationCache[conqueror][timelessJewelType] = make(map[uint32]map[uint32]data.AlternatePassiveSkillInformation)
-	}
+	cache := e.getJewelCache(timelessJewelType, conqueror)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 
 	results := make(map[uint32]map[uint32]map[uint32]uint32)
 
@@ -84,29 +132,37 @@ func ReverseSearch(passiveIDs []uint32, statIDs []uint32, timelessJewelType data
 		seedMax /= 20
 	}
 
-	rng := random.NewRNG()
+	rng := e.getRNG()
+	defer e.putRNG(rng)
+
 	for seed := seedMin; seed <= seedMax; seed++ {
 		realSeed := seed
 		if data.TimelessJewelSeedRanges[timelessJewelType].Special {
 			realSeed *= 20
 		}
 
-		if seed%10 == 0 && updates != nil {
-			updates(realSeed)
+		if seed%10 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("reverse search stopped at seed %d: %w", realSeed, err)
+			}
+
+			if updates != nil {
+				updates(realSeed)
+			}
 		}
 
 		timelessJewel.Seed = realSeed
 		alternateTreeManager.TimelessJewel = timelessJewel
 
-		if _, ok := calculationCache[conqueror][timelessJewelType][realSeed]; !ok {
-			calculationCache[conqueror][timelessJewelType][realSeed] = make(map[uint32]data.AlternatePassiveSkillInformation)
+		if _, ok := cache.seeds[realSeed]; !ok {
+			cache.seeds[realSeed] = make(map[uint32]data.AlternatePassiveSkillInformation)
 		}
 
 		for _, skill := range passiveSkills {
 			alternateTreeManager.PassiveSkill = skill
 
 			var result data.AlternatePassiveSkillInformation
-			if cacheHit, ok := calculationCache[conqueror][timelessJewelType][realSeed][skill.Index]; ok {
+			if cacheHit, ok := cache.seeds[realSeed][skill.Index]; ok {
 				result = cacheHit
 			} else {
 				if alternateTreeManager.IsPassiveSkillReplaced(rng) {
@@ -116,7 +172,7 @@ func ReverseSearch(passiveIDs []uint32, statIDs []uint32, timelessJewelType data
 						AlternatePassiveAdditionInformations: alternateTreeManager.AugmentPassiveSkill(rng),
 					}
 				}
-				calculationCache[conqueror][timelessJewelType][realSeed][skill.Index] = result
+				cache.seeds[realSeed][skill.Index] = result
 			}
 
 			if result.AlternatePassiveSkill != nil {
@@ -159,9 +215,43 @@ func ReverseSearch(passiveIDs []uint32, statIDs []uint32, timelessJewelType data
 		}
 	}
 
-	return results
+	return results, nil
 }
 
+// ClearCache is Engine.ClearCache on the default engine
 func ClearCache() {
-	calculationCache = make(map[data.Conqueror]map[data.JewelType]map[uint32]map[uint32]data.AlternatePassiveSkillInformation)
+	defaultEngine.ClearCache()
+}
+
+// ClearCache drops every cached calculation of the engine
+func (e *Engine) ClearCache() {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	e.cache = make(map[data.Conqueror]map[data.JewelType]*jewelCache)
+}
+
+// dropStaleCache clears the cache once the engine follows a new default dataset, cacheMu must be held
+func (e *Engine) dropStaleCache() {
+	if dataset := e.Dataset(); e.cacheDataset != dataset {
+		e.cache = make(map[data.Conqueror]map[data.JewelType]*jewelCache)
+		e.cacheDataset = dataset
+	}
+}
+
+func (e *Engine) isCached(timelessJewelType data.JewelType, conqueror data.Conqueror) bool {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	e.dropStaleCache()
+
+	_, ok := e.cache[conqueror][timelessJewelType]
+	return ok
+}
+
+func (e *Engine) dropCache(timelessJewelType data.JewelType, conqueror data.Conqueror) {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	delete(e.cache[conqueror], timelessJewelType)
 }
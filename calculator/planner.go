@@ -0,0 +1,94 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// Plan is a recommended set of passives to allocate for a jewel in a socket
+type Plan struct {
+	Seed   uint32
+	Socket uint32
+	// Nodes holds the graph IDs to allocate in order, starting with the path to the socket
+	Nodes []uint32
+	// Points is the number of passive points the plan spends
+	Points uint32
+	// Weight is the value of every transformed passive allocated once the plan is taken
+	Weight float64
+	// Captured holds the scores of the transformed passives allocated once the plan is taken
+	Captured []NodeScore
+}
+
+// PlanAllocation is Engine.PlanAllocation on the default engine
+func PlanAllocation(allocatedGraphIDs []uint32, classID int, socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, stats []StatWeight, budget uint32) (*Plan, error) {
+	return defaultEngine.PlanAllocation(allocatedGraphIDs, classID, socketGraphID, seed, timelessJewelType, conqueror, stats, budget)
+}
+
+// PlanAllocation recommends the cheapest extra passives that capture the most valuable transformed passives
+// of a seed in a socket. Budget limits the points spent including the socket path, zero is unlimited.
+func (e *Engine) PlanAllocation(allocatedGraphIDs []uint32, classID int, socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, stats []StatWeight, budget uint32) (*Plan, error) {
+	graph := e.Tree().Graph()
+
+	socketPlan, ok := graph.PlanSocket(allocatedGraphIDs, classID, socketGraphID)
+	if !ok {
+		return nil, fmt.Errorf("socket %d is not reachable from class %d", socketGraphID, classID)
+	}
+
+	if budget > 0 && socketPlan.SocketCost > budget {
+		return nil, fmt.Errorf("socket %d needs %d points, more than the budget of %d", socketGraphID, socketPlan.SocketCost, budget)
+	}
+
+	score := e.ScoreSocket(socketGraphID, seed, timelessJewelType, conqueror, SearchOptions{Stats: stats})
+
+	values := make(map[uint32]float64, len(score.Nodes))
+	nodes := make(map[uint32]NodeScore, len(score.Nodes))
+	for _, node := range score.Nodes {
+		skill := e.Dataset().GetPassiveSkillByIndex(node.Passive)
+		if skill == nil {
+			continue
+		}
+
+		graphID := skill.PassiveSkillGraphID
+		values[graphID] = node.Weight
+		nodes[graphID] = node
+	}
+
+	remaining := uint32(math.MaxUint32)
+	if budget > 0 {
+		remaining = budget - socketPlan.SocketCost
+	}
+
+	added, weight := graph.Grow(socketPlan.Allocated, values, remaining)
+
+	plan := &Plan{
+		Seed:   seed,
+		Socket: socketGraphID,
+		Nodes:  append(append([]uint32{}, socketPlan.SocketPath...), added...),
+		Weight: weight,
+	}
+	plan.Points = uint32(len(plan.Nodes))
+
+	allocated := make(map[uint32]bool, len(socketPlan.Allocated)+len(added))
+	for _, id := range socketPlan.Allocated {
+		allocated[id] = true
+	}
+	for _, id := range added {
+		allocated[id] = true
+	}
+
+	for graphID, node := range nodes {
+		if allocated[graphID] {
+			node.Allocated = true
+			plan.Captured = append(plan.Captured, node)
+		}
+	}
+
+	sort.Slice(plan.Captured, func(i, j int) bool {
+		return plan.Captured[i].Passive < plan.Captured[j].Passive
+	})
+
+	return plan, nil
+}
@@ -0,0 +1,278 @@
+package calculator
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// StatWeight mirrors the stat configuration of the web UI search
+type StatWeight struct {
+	ID     uint32
+	Weight float64
+	// Min is the number of passives that must roll the stat
+	Min uint32
+}
+
+// Allocation describes the passives a build has allocated and what it costs to reach the others
+type Allocation struct {
+	// Allocated holds passive indices
+	Allocated map[uint32]bool
+	// PathCosts holds the passive points needed to allocate each unallocated passive index,
+	// passives missing from it are unreachable and left out of scores
+	PathCosts map[uint32]uint32
+	// SocketCost is the passive points needed to reach the jewel socket
+	SocketCost uint32
+}
+
+func NewAllocation(passiveIDs []uint32) *Allocation {
+	allocated := make(map[uint32]bool, len(passiveIDs))
+	for _, id := range passiveIDs {
+		allocated[id] = true
+	}
+
+	return &Allocation{
+		Allocated: allocated,
+		PathCosts: make(map[uint32]uint32),
+	}
+}
+
+type SearchOptions struct {
+	Stats          []StatWeight
+	MinTotalWeight float64
+
+	// Allocation restricts scoring to allocated passives, nil counts every passive as allocated
+	Allocation *Allocation
+
+	// UnallocatedWeight scales the value of unallocated passives into Potential, divided by one plus their path cost.
+	// Zero only counts allocated passives.
+	UnallocatedWeight float64
+
+	// PassiveTypes restricts matches to passives of these types, empty allows every type
+	PassiveTypes []data.PassiveSkillType
+}
+
+type NodeScore struct {
+	Passive   uint32
+	Stats     map[uint32]uint32
+	Weight    float64
+	Allocated bool
+	PathCost  uint32
+}
+
+type SeedScore struct {
+	Seed uint32
+	// Weight is the value of the seed as allocated
+	Weight float64
+	// Potential is the path cost adjusted value of unallocated passives
+	Potential float64
+	// PotentialCost is the total path cost of the unallocated passives counted in Potential
+	PotentialCost uint32
+	// PointCost is the socket cost plus PotentialCost
+	PointCost  uint32
+	StatCounts map[uint32]int
+	Nodes      []NodeScore
+}
+
+// Total is the value used for ranking and MinTotalWeight
+func (s SeedScore) Total() float64 {
+	return s.Weight + s.Potential
+}
+
+// ValuePerPoint divides Total by the passive points it costs, at least one
+func (s SeedScore) ValuePerPoint() float64 {
+	return s.Total() / float64(max(s.PointCost, 1))
+}
+
+func (o SearchOptions) statMap() map[uint32]bool {
+	statMap := make(map[uint32]bool, len(o.Stats))
+	for _, stat := range o.Stats {
+		statMap[stat.ID] = true
+	}
+	return statMap
+}
+
+func (o SearchOptions) statIDs() []uint32 {
+	ids := make([]uint32, 0, len(o.Stats))
+	for _, stat := range o.Stats {
+		ids = append(ids, stat.ID)
+	}
+	return ids
+}
+
+// allowsPassive checks a passive index against PassiveTypes
+func (o SearchOptions) allowsPassive(dataset *data.Dataset, passiveID uint32) bool {
+	if len(o.PassiveTypes) == 0 {
+		return true
+	}
+
+	skill := dataset.GetPassiveSkillByIndex(passiveID)
+	return skill != nil && slices.Contains(o.PassiveTypes, data.GetPassiveSkillType(skill))
+}
+
+// FilterPassiveTypes is Engine.FilterPassiveTypes on the default engine
+func FilterPassiveTypes(passiveIDs []uint32, types []data.PassiveSkillType) []uint32 {
+	return defaultEngine.FilterPassiveTypes(passiveIDs, types)
+}
+
+// FilterPassiveTypes keeps the passive indices of the given types, empty types keep everything
+func (e *Engine) FilterPassiveTypes(passiveIDs []uint32, types []data.PassiveSkillType) []uint32 {
+	options := SearchOptions{PassiveTypes: types}
+	dataset := e.Dataset()
+
+	filtered := make([]uint32, 0, len(passiveIDs))
+	for _, id := range passiveIDs {
+		if options.allowsPassive(dataset, id) {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+func (o SearchOptions) score(dataset *data.Dataset, seed uint32, passives map[uint32]map[uint32]uint32) SeedScore {
+	weights := make(map[uint32]float64, len(o.Stats))
+	for _, stat := range o.Stats {
+		weights[stat.ID] = stat.Weight
+	}
+
+	score := SeedScore{
+		Seed:       seed,
+		StatCounts: make(map[uint32]int),
+		Nodes:      make([]NodeScore, 0, len(passives)),
+	}
+
+	for passiveID, stats := range passives {
+		if !o.allowsPassive(dataset, passiveID) {
+			continue
+		}
+
+		node := NodeScore{
+			Passive:   passiveID,
+			Stats:     stats,
+			Allocated: o.Allocation == nil || o.Allocation.Allocated[passiveID],
+		}
+
+		for stat := range stats {
+			node.Weight += weights[stat]
+		}
+
+		counted := node.Allocated
+		if node.Allocated {
+			score.Weight += node.Weight
+		} else {
+			cost, ok := o.Allocation.PathCosts[passiveID]
+			if !ok {
+				// Unreachable passives can not add to the potential of a seed
+				continue
+			}
+
+			node.PathCost = cost
+			if o.UnallocatedWeight > 0 {
+				score.Potential += node.Weight * o.UnallocatedWeight / float64(1+node.PathCost)
+				score.PotentialCost += node.PathCost
+				counted = true
+			}
+		}
+
+		if counted {
+			for stat := range stats {
+				score.StatCounts[stat]++
+			}
+		}
+
+		score.Nodes = append(score.Nodes, node)
+	}
+
+	if o.Allocation != nil {
+		score.PointCost = o.Allocation.SocketCost + score.PotentialCost
+	}
+
+	sort.Slice(score.Nodes, func(i, j int) bool {
+		return score.Nodes[i].Passive < score.Nodes[j].Passive
+	})
+
+	return score
+}
+
+// Matches applies MinTotalWeight and the per stat minimums
+func (o SearchOptions) Matches(score SeedScore) bool {
+	if score.Total() < o.MinTotalWeight {
+		return false
+	}
+
+	for _, stat := range o.Stats {
+		if uint32(score.StatCounts[stat.ID]) < stat.Min {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ScoreResults is Engine.ScoreResults on the default engine
+func ScoreResults(results map[uint32]map[uint32]map[uint32]uint32, options SearchOptions) []SeedScore {
+	return defaultEngine.ScoreResults(results, options)
+}
+
+// ScoreResults scores ReverseSearch results, dropping seeds that do not match and sorting the rest by total value
+func (e *Engine) ScoreResults(results map[uint32]map[uint32]map[uint32]uint32, options SearchOptions) []SeedScore {
+	dataset := e.Dataset()
+
+	scores := make([]SeedScore, 0, len(results))
+	for seed, passives := range results {
+		score := options.score(dataset, seed, passives)
+		if options.Matches(score) {
+			scores = append(scores, score)
+		}
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Total() != scores[j].Total() {
+			return scores[i].Total() > scores[j].Total()
+		}
+		return scores[i].Seed < scores[j].Seed
+	})
+
+	return scores
+}
+
+// RankByValuePerPoint sorts scores by value per passive point spent, then by total value
+func RankByValuePerPoint(scores []SeedScore) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].ValuePerPoint() != scores[j].ValuePerPoint() {
+			return scores[i].ValuePerPoint() > scores[j].ValuePerPoint()
+		}
+		if scores[i].Total() != scores[j].Total() {
+			return scores[i].Total() > scores[j].Total()
+		}
+		return scores[i].Seed < scores[j].Seed
+	})
+}
+
+// Search is Engine.Search on the default engine
+func Search(passiveIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, options SearchOptions, updates UpdateFunc) []SeedScore {
+	return defaultEngine.Search(passiveIDs, timelessJewelType, conqueror, options, updates)
+}
+
+// SearchContext is Engine.SearchContext on the default engine
+func SearchContext(ctx context.Context, passiveIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, options SearchOptions, updates UpdateFunc) ([]SeedScore, error) {
+	return defaultEngine.SearchContext(ctx, passiveIDs, timelessJewelType, conqueror, options, updates)
+}
+
+// Search runs ReverseSearch for the weighted stats and scores every seed against the allocation
+func (e *Engine) Search(passiveIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, options SearchOptions, updates UpdateFunc) []SeedScore {
+	scores, _ := e.SearchContext(context.Background(), passiveIDs, timelessJewelType, conqueror, options, updates)
+	return scores
+}
+
+// SearchContext is Search stopping early once the context is done
+func (e *Engine) SearchContext(ctx context.Context, passiveIDs []uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, options SearchOptions, updates UpdateFunc) ([]SeedScore, error) {
+	results, err := e.ReverseSearchContext(ctx, e.FilterPassiveTypes(passiveIDs, options.PassiveTypes), options.statIDs(), timelessJewelType, conqueror, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.ScoreResults(results, options), nil
+}
@@ -0,0 +1,97 @@
+package calculator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+)
+
+// Jewel identifies a single timeless jewel roll
+type Jewel struct {
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Seed      uint32
+}
+
+// NodeDiff holds how each compared jewel transforms a passive
+type NodeDiff struct {
+	Passive uint32
+	GraphID uint32
+	Name    string
+	// Jewels holds one comparison per compared jewel, in order
+	Jewels []NodeComparison
+	// Differs is set when the jewels leave the passive with different names, stats or rolls
+	Differs bool
+}
+
+type SeedComparison struct {
+	Socket uint32
+	Jewels []Jewel
+	Nodes  []NodeDiff
+	// Scores holds the score of each jewel under the query, in order
+	Scores []SeedScore
+	// Deltas holds the total score of each jewel minus the first
+	Deltas []float64
+}
+
+func sameLines(a []StatLine, b []StatLine) bool {
+	return slices.EqualFunc(a, b, func(x StatLine, y StatLine) bool {
+		return x.Text == y.Text && x.Roll == y.Roll
+	})
+}
+
+// CompareSeeds is Engine.CompareSeeds on the default engine
+func CompareSeeds(socketGraphID uint32, jewels []Jewel, options SearchOptions, lang data.Language) (*SeedComparison, error) {
+	return defaultEngine.CompareSeeds(socketGraphID, jewels, options, lang)
+}
+
+// CompareSeeds diffs two or more jewels in the same socket node by node and scores each under the query
+func (e *Engine) CompareSeeds(socketGraphID uint32, jewels []Jewel, options SearchOptions, lang data.Language) (*SeedComparison, error) {
+	if len(jewels) < 2 {
+		return nil, fmt.Errorf("need at least two jewels to compare, got %d", len(jewels))
+	}
+
+	for _, jewel := range jewels {
+		if err := item.Validate(jewel.JewelType, jewel.Conqueror, jewel.Seed); err != nil {
+			return nil, fmt.Errorf("invalid jewel: %w", err)
+		}
+	}
+
+	comparison := &SeedComparison{
+		Socket: socketGraphID,
+		Jewels: jewels,
+		Scores: make([]SeedScore, len(jewels)),
+		Deltas: make([]float64, len(jewels)),
+	}
+
+	perJewel := make([][]NodeComparison, len(jewels))
+	for i, jewel := range jewels {
+		perJewel[i] = e.CompareSocket(socketGraphID, jewel.Seed, jewel.JewelType, jewel.Conqueror, lang)
+		comparison.Scores[i] = e.ScoreSocket(socketGraphID, jewel.Seed, jewel.JewelType, jewel.Conqueror, options)
+		comparison.Deltas[i] = comparison.Scores[i].Total() - comparison.Scores[0].Total()
+	}
+
+	// CompareSocket orders by graph ID, so every jewel lists the same passives in the same order
+	for n, first := range perJewel[0] {
+		diff := NodeDiff{
+			Passive: first.Passive,
+			GraphID: first.GraphID,
+			Name:    first.Name,
+			Jewels:  make([]NodeComparison, len(jewels)),
+		}
+
+		for i := range jewels {
+			node := perJewel[i][n]
+			diff.Jewels[i] = node
+			if node.NewName != first.NewName || !sameLines(node.After, first.After) {
+				diff.Differs = true
+			}
+		}
+
+		comparison.Nodes = append(comparison.Nodes, diff)
+	}
+
+	return comparison, nil
+}
@@ -0,0 +1,130 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+// CalculateSocket is Engine.CalculateSocket on the default engine
+func CalculateSocket(socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror) map[uint32]data.AlternatePassiveSkillInformation {
+	return defaultEngine.CalculateSocket(socketGraphID, seed, timelessJewelType, conqueror)
+}
+
+// SocketPassiveIDs is Engine.SocketPassiveIDs on the default engine
+func SocketPassiveIDs(socketGraphID uint32) []uint32 {
+	return defaultEngine.SocketPassiveIDs(socketGraphID)
+}
+
+// ScoreSocket is Engine.ScoreSocket on the default engine
+func ScoreSocket(socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, options SearchOptions) SeedScore {
+	return defaultEngine.ScoreSocket(socketGraphID, seed, timelessJewelType, conqueror, options)
+}
+
+// CalculateSocket transforms every passive in the radius of a socket, keyed by passive index
+func (e *Engine) CalculateSocket(socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror) map[uint32]data.AlternatePassiveSkillInformation {
+	results := make(map[uint32]data.AlternatePassiveSkillInformation)
+	for _, skill := range e.Tree().PassivesInRadius(socketGraphID) {
+		if !data.IsPassiveSkillValidForAlteration(skill) {
+			continue
+		}
+
+		results[skill.Index] = e.Calculate(skill.Index, seed, timelessJewelType, conqueror)
+	}
+	return results
+}
+
+// SocketPassiveIDs returns the passive indices a jewel in the socket transforms, as used by ReverseSearch
+func (e *Engine) SocketPassiveIDs(socketGraphID uint32) []uint32 {
+	passives := e.Tree().PassivesInRadius(socketGraphID)
+	ids := make([]uint32, 0, len(passives))
+	for _, skill := range passives {
+		ids = append(ids, skill.Index)
+	}
+	return ids
+}
+
+// ScoreSocket calculates a single seed in a socket and scores it like a search result
+func (e *Engine) ScoreSocket(socketGraphID uint32, seed uint32, timelessJewelType data.JewelType, conqueror data.Conqueror, options SearchOptions) SeedScore {
+	statMap := options.statMap()
+
+	passives := make(map[uint32]map[uint32]uint32)
+	for passiveID, result := range e.CalculateSocket(socketGraphID, seed, timelessJewelType, conqueror) {
+		if stats := matchingStats(result, statMap); len(stats) > 0 {
+			passives[passiveID] = stats
+		}
+	}
+
+	return options.score(e.Dataset(), seed, passives)
+}
+
+// matchingStats collects the rolls of the wanted stats from a replacement and its additions
+func matchingStats(result data.AlternatePassiveSkillInformation, statMap map[uint32]bool) map[uint32]uint32 {
+	stats := make(map[uint32]uint32)
+
+	if result.AlternatePassiveSkill != nil {
+		for i, key := range result.AlternatePassiveSkill.StatsKeys {
+			if statMap[key] && result.StatRolls != nil {
+				stats[key] = result.StatRolls[uint32(i)]
+			}
+		}
+	}
+
+	for _, augment := range result.AlternatePassiveAdditionInformations {
+		if augment.AlternatePassiveAddition == nil {
+			continue
+		}
+
+		for i, key := range augment.AlternatePassiveAddition.StatsKeys {
+			if statMap[key] && augment.StatRolls != nil {
+				stats[key] = augment.StatRolls[uint32(i)]
+			}
+		}
+	}
+
+	return stats
+}
+
+// SocketAllocation is Engine.SocketAllocation on the default engine
+func SocketAllocation(allocation *tree.Allocation, socketGraphID uint32) (*Allocation, error) {
+	return defaultEngine.SocketAllocation(allocation, socketGraphID)
+}
+
+// SocketAllocation builds an Allocation from a tree allocation with the path costs of jewelling a socket.
+// Paths start from the class start and every allocated node.
+func (e *Engine) SocketAllocation(allocation *tree.Allocation, socketGraphID uint32) (*Allocation, error) {
+	passiveTree := e.Tree()
+	dataset := passiveTree.Dataset()
+
+	known := make([]uint32, 0, len(allocation.Nodes))
+	allocated := make([]uint32, 0, len(allocation.Nodes))
+	for _, graphID := range allocation.Nodes {
+		if _, ok := passiveTree.Node(graphID); !ok {
+			continue
+		}
+		known = append(known, graphID)
+
+		if skill := dataset.GetPassiveSkillByGraphID(graphID); skill != nil {
+			allocated = append(allocated, skill.Index)
+		}
+	}
+
+	plan, ok := passiveTree.Graph().PlanSocket(known, allocation.ClassID, socketGraphID)
+	if !ok {
+		return nil, fmt.Errorf("socket %d is not reachable from class %d", socketGraphID, allocation.ClassID)
+	}
+
+	result := NewAllocation(allocated)
+	result.SocketCost = plan.SocketCost
+
+	for graphID, cost := range plan.NodeCosts {
+		skill := dataset.GetPassiveSkillByGraphID(graphID)
+		if skill == nil || result.Allocated[skill.Index] {
+			continue
+		}
+		result.PathCosts[skill.Index] = cost
+	}
+
+	return result, nil
+}
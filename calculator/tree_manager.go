@@ -8,6 +8,15 @@ import (
 type AlternateTreeManager struct {
 	PassiveSkill  *data.PassiveSkill
 	TimelessJewel data.TimelessJewel
+	// Dataset holds the alternate passives to roll, nil uses data.Default
+	Dataset *data.Dataset
+}
+
+func (a *AlternateTreeManager) dataset() *data.Dataset {
+	if a.Dataset == nil {
+		return data.Default()
+	}
+	return a.Dataset
 }
 
 func (a *AlternateTreeManager) IsPassiveSkillReplaced(rng *random.NumberGenerator) bool {
@@ -46,7 +55,7 @@ func (a *AlternateTreeManager) AugmentPassiveSkill(rng *random.NumberGenerator)
 }
 
 func (a *AlternateTreeManager) RollAlternatePassiveAddition(rng *random.NumberGenerator) *data.AlternatePassiveAddition {
-	applicableAlternatePassiveAdditions := data.GetApplicableAlternatePassiveAdditions(a.PassiveSkill, a.TimelessJewel)
+	applicableAlternatePassiveAdditions := a.dataset().GetApplicableAlternatePassiveAdditions(a.PassiveSkill, a.TimelessJewel)
 
 	totalSpawnWeight := uint32(0)
 	for _, addition := range applicableAlternatePassiveAdditions {
@@ -68,7 +77,7 @@ func (a *AlternateTreeManager) RollAlternatePassiveAddition(rng *random.NumberGe
 
 func (a *AlternateTreeManager) ReplacePassiveSkill(rng *random.NumberGenerator) data.AlternatePassiveSkillInformation {
 	if a.PassiveSkill.IsKeystone {
-		alternatePassiveSkillKeyStone := data.GetAlternatePassiveSkillKeyStone(a.TimelessJewel)
+		alternatePassiveSkillKeyStone := a.dataset().GetAlternatePassiveSkillKeyStone(a.TimelessJewel)
 		return data.AlternatePassiveSkillInformation{
 			AlternatePassiveSkill: alternatePassiveSkillKeyStone,
 			StatRolls: map[uint32]uint32{
@@ -77,7 +86,7 @@ func (a *AlternateTreeManager) ReplacePassiveSkill(rng *random.NumberGenerator)
 		}
 	}
 
-	applicableAlternatePassiveSkills := data.GetApplicableAlternatePassiveSkills(a.PassiveSkill, a.TimelessJewel)
+	applicableAlternatePassiveSkills := a.dataset().GetApplicableAlternatePassiveSkills(a.PassiveSkill, a.TimelessJewel)
 
 	var rolledAlternatePassiveSkill *data.AlternatePassiveSkill
 	rng.Reset(a.PassiveSkill, a.TimelessJewel)
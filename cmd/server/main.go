@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/BlazesRus/timeless-jewels/api"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "address to listen on")
+	retention := flag.Duration("retention", api.DefaultRetention, "how long finished jobs are kept")
+	dataDir := flag.String("data", "", "directory of game data files to use instead of the embedded data")
+	flag.Parse()
+
+	if *dataDir != "" {
+		if err := data.LoadDefaultDir(*dataDir); err != nil {
+			slog.Error("failed to load game data", slog.Any("err", err))
+			os.Exit(1)
+		}
+	}
+
+	jobs := api.NewJobs()
+	jobs.Retention = *retention
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           api.NewHandler(jobs),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Ends open event streams on shutdown
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdown); err != nil {
+			slog.Error("failed to shut down", slog.Any("err", err))
+		}
+	}()
+
+	slog.Info("listening", slog.String("addr", *addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("server stopped", slog.Any("err", err))
+		os.Exit(1)
+	}
+}
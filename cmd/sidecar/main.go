@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/BlazesRus/timeless-jewels/api"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// Speaks JSON-RPC 2.0 over stdin and stdout, one message per line, logging to stderr
+func main() {
+	dataDir := flag.String("data", "", "directory of game data files to use instead of the embedded data")
+	flag.Parse()
+
+	if *dataDir != "" {
+		if err := data.LoadDefaultDir(*dataDir); err != nil {
+			slog.Error("failed to load game data", slog.Any("err", err))
+			os.Exit(1)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := api.NewRPCServer(os.Stdout).Serve(ctx, os.Stdin); err != nil {
+		slog.Error("sidecar stopped", slog.Any("err", err))
+		os.Exit(1)
+	}
+}
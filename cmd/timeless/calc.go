@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/export"
+	"github.com/BlazesRus/timeless-jewels/item"
+)
+
+func runCalc(args []string) error {
+	flags := newFlagSet("calc", "-jewel <type> -conqueror <name> -seed <seed> (-socket <graph id> | -node <graph id> | -passive <index>)")
+
+	var jewel jewelFlags
+	jewel.register(flags, true)
+
+	socket := flags.Uint("socket", 0, "jewel socket graph ID, calculates every passive in radius")
+	node := flags.Uint("node", 0, "passive graph ID")
+	passive := flags.Uint("passive", 0, "passive skill index")
+	lang := flags.String("lang", string(data.English), "language of the stat text")
+	format := flags.String("format", TableFormat, "output format: table, json, csv or ndjson")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	jewelType, conqueror, err := jewel.parse()
+	if err != nil {
+		return err
+	}
+
+	seed := uint32(jewel.seed)
+	if err := item.Validate(jewelType, conqueror, seed); err != nil {
+		return err
+	}
+
+	language := data.Language(*lang)
+
+	var comparisons []calculator.NodeComparison
+	switch {
+	case *socket != 0:
+		comparisons = calculator.CompareSocket(uint32(*socket), seed, jewelType, conqueror, language)
+	case *node != 0:
+		skill := data.GetPassiveSkillByGraphID(uint32(*node))
+		if skill == nil || !data.IsPassiveSkillValidForAlteration(skill) {
+			return errors.New("node is not a passive a jewel can transform")
+		}
+		comparisons = append(comparisons, calculator.CompareNode(skill.Index, seed, jewelType, conqueror, language))
+	case *passive != 0:
+		if skill := data.GetPassiveSkillByIndex(uint32(*passive)); skill == nil || !data.IsPassiveSkillValidForAlteration(skill) {
+			return errors.New("passive index is not a passive a jewel can transform")
+		}
+		comparisons = append(comparisons, calculator.CompareNode(uint32(*passive), seed, jewelType, conqueror, language))
+	default:
+		flags.Usage()
+		return errors.New("one of -socket, -node or -passive is required")
+	}
+
+	out := output{
+		headers: []string{"Node", "Passive", "Name", "Result", "Change", "Stat"},
+		value:   comparisons,
+		csv: func(w io.Writer) error {
+			return export.WriteSocketCSV(w, seed, comparisons)
+		},
+		ndjson: func(w io.Writer) error {
+			return export.WriteNDJSON(w, comparisons)
+		},
+	}
+
+	for _, comparison := range comparisons {
+		result := "augmented"
+		if comparison.Replaced {
+			result = "replaced"
+		}
+
+		for _, line := range comparison.Before {
+			if line.Lost {
+				out.add(comparison.GraphID, comparison.Passive, comparison.Name, result, "lost", line.Text)
+			}
+		}
+
+		for _, line := range comparison.After {
+			change := "kept"
+			if line.Added {
+				change = "added"
+			}
+			out.add(comparison.GraphID, comparison.Passive, comparison.NewName, result, change, line.Text)
+		}
+	}
+
+	return out.write(os.Stdout, *format)
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"atomicgo.dev/keyboard"
+	"atomicgo.dev/keyboard/keys"
+	"github.com/pterm/pterm"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+type exploreAction int
+
+const (
+	quitAction = exploreAction(iota)
+	filterAction
+)
+
+const exploreHelp = "←/→ seed  ↑/↓ 10 seeds  PgUp/PgDn 100 seeds  Home/End first/last  n/p next/previous match  f filters  c clear filters  s small passives  q quit"
+
+type explorer struct {
+	jewelType data.JewelType
+	conqueror data.Conqueror
+	socket    uint32
+	seed      uint32
+	lang      data.Language
+
+	seeds data.Range
+
+	// filters holds the stat indices to highlight, matches the seeds rolling any of them
+	filters map[uint32]bool
+	matches []uint32
+
+	// small shows small passives, otherwise only notables and keystones are listed
+	small bool
+
+	area *pterm.AreaPrinter
+}
+
+func runExplore(args []string) error {
+	flags := newFlagSet("explore", "[-jewel <type>] [-conqueror <name>] [-socket <graph id>] [-seed <seed>]")
+
+	var jewel jewelFlags
+	jewel.register(flags, true)
+
+	socket := flags.Uint("socket", 0, "jewel socket graph ID")
+	lang := flags.String("lang", string(data.English), "language of the stat text")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	e := &explorer{
+		lang:    data.Language(*lang),
+		socket:  uint32(*socket),
+		filters: make(map[uint32]bool),
+	}
+
+	if err := e.pickJewel(jewel); err != nil {
+		return err
+	}
+
+	// Without -seed the explorer starts at the first seed of the range
+	if jewel.seed != 0 {
+		if err := item.Validate(e.jewelType, e.conqueror, uint32(jewel.seed)); err != nil {
+			return err
+		}
+	}
+
+	if e.socket == 0 {
+		if err := e.pickSocket(); err != nil {
+			return err
+		}
+	}
+
+	e.seeds = data.TimelessJewelSeedRanges[e.jewelType]
+	e.seed = e.clamp(int64(jewel.seed))
+
+	return e.run()
+}
+
+func (e *explorer) pickJewel(jewel jewelFlags) error {
+	if jewel.jewel == "" {
+		names := make([]string, 0, len(data.TimelessJewelConquerors))
+		for jewelType := range data.TimelessJewelConquerors {
+			names = append(names, jewelType.String())
+		}
+		sort.Strings(names)
+
+		name, err := pterm.DefaultInteractiveSelect.WithOptions(names).Show("Jewel")
+		if err != nil {
+			return fmt.Errorf("failed to pick a jewel: %w", err)
+		}
+		jewel.jewel = name
+	}
+
+	jewelType, err := parseJewelType(jewel.jewel)
+	if err != nil {
+		return err
+	}
+
+	if jewel.conqueror == "" {
+		names := make([]string, 0, len(data.TimelessJewelConquerors[jewelType]))
+		for conqueror := range data.TimelessJewelConquerors[jewelType] {
+			names = append(names, string(conqueror))
+		}
+		sort.Strings(names)
+
+		name, err := pterm.DefaultInteractiveSelect.WithOptions(names).Show("Conqueror")
+		if err != nil {
+			return fmt.Errorf("failed to pick a conqueror: %w", err)
+		}
+		jewel.conqueror = name
+	}
+
+	e.jewelType, e.conqueror, err = jewel.parse()
+	return err
+}
+
+// socketLabel names a socket after the notables in its radius
+func socketLabel(socket uint32) string {
+	notables := make([]string, 0)
+	for _, skill := range tree.PassivesInRadius(socket) {
+		if skill.IsNotable {
+			notables = append(notables, skill.Name)
+		}
+	}
+	sort.Strings(notables)
+
+	if len(notables) > 3 {
+		notables = append(notables[:3], "...")
+	}
+
+	return fmt.Sprintf("%d: %s", socket, strings.Join(notables, ", "))
+}
+
+func (e *explorer) pickSocket() error {
+	labels := make([]string, 0)
+	for _, socket := range tree.JewelSockets() {
+		labels = append(labels, socketLabel(socket))
+	}
+	sort.Strings(labels)
+
+	label, err := pterm.DefaultInteractiveSelect.WithOptions(labels).WithMaxHeight(15).Show("Socket")
+	if err != nil {
+		return fmt.Errorf("failed to pick a socket: %w", err)
+	}
+
+	socket, err := strconv.ParseUint(strings.SplitN(label, ":", 2)[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid socket %q: %w", label, err)
+	}
+
+	e.socket = uint32(socket)
+	return nil
+}
+
+func (e *explorer) step() int64 {
+	if e.seeds.Special {
+		return 20
+	}
+	return 1
+}
+
+func (e *explorer) clamp(seed int64) uint32 {
+	seed = max(int64(e.seeds.Min), min(int64(e.seeds.Max), seed))
+	return uint32(seed - seed%e.step())
+}
+
+func (e *explorer) move(steps int64) {
+	e.seed = e.clamp(int64(e.seed) + steps*e.step())
+}
+
+// jump moves to the next or previous seed rolling a filtered stat
+func (e *explorer) jump(forward bool) {
+	if forward {
+		i := sort.Search(len(e.matches), func(i int) bool { return e.matches[i] > e.seed })
+		if i < len(e.matches) {
+			e.seed = e.matches[i]
+		}
+		return
+	}
+
+	i := sort.Search(len(e.matches), func(i int) bool { return e.matches[i] >= e.seed })
+	if i > 0 {
+		e.seed = e.matches[i-1]
+	}
+}
+
+func (e *explorer) run() error {
+	for {
+		area, err := pterm.DefaultArea.Start(e.render())
+		if err != nil {
+			return fmt.Errorf("failed to start display: %w", err)
+		}
+		e.area = area
+
+		action, err := e.listen()
+		if stopErr := area.Stop(); stopErr != nil && err == nil {
+			err = fmt.Errorf("failed to stop display: %w", stopErr)
+		}
+
+		if err != nil || action == quitAction {
+			return err
+		}
+
+		if err := e.pickFilters(); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *explorer) listen() (exploreAction, error) {
+	action := quitAction
+
+	err := keyboard.Listen(func(key keys.Key) (bool, error) {
+		switch key.Code {
+		case keys.Right:
+			e.move(1)
+		case keys.Left:
+			e.move(-1)
+		case keys.Up:
+			e.move(10)
+		case keys.Down:
+			e.move(-10)
+		case keys.PgUp:
+			e.move(100)
+		case keys.PgDown:
+			e.move(-100)
+		case keys.Home:
+			e.seed = e.clamp(int64(e.seeds.Min))
+		case keys.End:
+			e.seed = e.clamp(int64(e.seeds.Max))
+		case keys.Esc, keys.CtrlC:
+			return true, nil
+		case keys.RuneKey:
+			switch key.String() {
+			case "q":
+				return true, nil
+			case "f":
+				action = filterAction
+				return true, nil
+			case "c":
+				e.setFilters(nil)
+			case "s":
+				e.small = !e.small
+			case "n":
+				e.jump(true)
+			case "p":
+				e.jump(false)
+			}
+		}
+
+		e.area.Update(e.render())
+		return false, nil
+	})
+	if err != nil {
+		return quitAction, fmt.Errorf("failed to read keys: %w", err)
+	}
+
+	return action, nil
+}
+
+func (e *explorer) pickFilters() error {
+	byLabel := make(map[string]uint32)
+	labels := make([]string, 0)
+	selected := make([]string, 0)
+	for stat := range data.PossibleStats[e.jewelType] {
+		label := fmt.Sprintf("%s (%d)", data.TranslateStatTemplate(e.lang, stat), stat)
+		byLabel[label] = stat
+		labels = append(labels, label)
+		if e.filters[stat] {
+			selected = append(selected, label)
+		}
+	}
+	sort.Strings(labels)
+
+	picked, err := pterm.DefaultInteractiveMultiselect.
+		WithOptions(labels).
+		WithDefaultOptions(selected).
+		WithMaxHeight(15).
+		Show("Stat filters (type to search, space to toggle, enter to confirm)")
+	if err != nil {
+		return fmt.Errorf("failed to pick filters: %w", err)
+	}
+
+	stats := make([]uint32, 0, len(picked))
+	for _, label := range picked {
+		stats = append(stats, byLabel[label])
+	}
+
+	e.setFilters(stats)
+	return nil
+}
+
+func (e *explorer) setFilters(stats []uint32) {
+	e.filters = make(map[uint32]bool, len(stats))
+	e.matches = nil
+
+	if len(stats) == 0 {
+		return
+	}
+
+	weights := make([]calculator.StatWeight, 0, len(stats))
+	for _, stat := range stats {
+		e.filters[stat] = true
+		weights = append(weights, calculator.StatWeight{ID: stat, Weight: 1})
+	}
+
+	results := calculator.Search(calculator.SocketPassiveIDs(e.socket), e.jewelType, e.conqueror, calculator.SearchOptions{Stats: weights}, nil)
+	for _, result := range results {
+		e.matches = append(e.matches, result.Seed)
+	}
+	sort.Slice(e.matches, func(i, j int) bool {
+		return e.matches[i] < e.matches[j]
+	})
+}
+
+func (e *explorer) hasFilteredStat(lines []calculator.StatLine) bool {
+	for _, line := range lines {
+		if line.StatIndex != nil && e.filters[*line.StatIndex] {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *explorer) render() string {
+	comparisons := calculator.CompareSocket(e.socket, e.seed, e.jewelType, e.conqueror, e.lang)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s  %s  socket %d  seed %s  (%d-%d)\n\n",
+		pterm.Bold.Sprint(e.jewelType.Localize(e.lang)),
+		e.conqueror.Localize(e.lang),
+		e.socket,
+		pterm.Bold.Sprint(e.seed),
+		e.seeds.Min,
+		e.seeds.Max,
+	)
+
+	lines := make([]string, 0)
+	shown := 0
+	for _, comparison := range comparisons {
+		if len(e.filters) > 0 && !e.hasFilteredStat(comparison.After) {
+			continue
+		}
+
+		if skill := data.GetPassiveSkillByIndex(comparison.Passive); !e.small && !skill.IsNotable && !skill.IsKeystone {
+			continue
+		}
+		shown++
+
+		name := comparison.Name
+		if comparison.Replaced {
+			name = fmt.Sprintf("%s → %s", comparison.Name, pterm.Cyan(comparison.NewName))
+		}
+		lines = append(lines, name)
+
+		for _, line := range comparison.After {
+			text := line.Text
+			switch {
+			case line.StatIndex != nil && e.filters[*line.StatIndex]:
+				text = pterm.Green("★ " + text)
+			case line.Added:
+				text = pterm.LightGreen("+ " + text)
+			default:
+				text = pterm.Gray("  " + text)
+			}
+			lines = append(lines, "    "+text)
+		}
+	}
+
+	// Keep the header and help on screen
+	if height := pterm.GetTerminalHeight() - 8; height > 0 && len(lines) > height {
+		hidden := len(lines) - height
+		lines = append(lines[:height], pterm.Gray(fmt.Sprintf("... %d more lines", hidden)))
+	}
+
+	for _, line := range lines {
+		fmt.Fprintln(&out, line)
+	}
+
+	if len(e.filters) > 0 {
+		fmt.Fprintf(&out, "\n%d nodes roll a filtered stat, %d matching seeds\n", shown, len(e.matches))
+	}
+
+	fmt.Fprintf(&out, "\n%s", pterm.Gray(exploreHelp))
+	return out.String()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/export"
+	"github.com/BlazesRus/timeless-jewels/item"
+	"github.com/BlazesRus/timeless-jewels/trade"
+)
+
+func runExport(args []string) error {
+	flags := newFlagSet("export", "-jewel <type> -conqueror <name> -seed <seed> [-as pob|game|trade | -as md|html|svg -socket <graph id>]")
+
+	var jewel jewelFlags
+	jewel.register(flags, true)
+
+	as := flags.String("as", "pob", "pob for Path of Building item text, game for in-game item text, trade for trade site links, md or html for a socket report, svg for an image of the socket")
+	socket := flags.Uint("socket", 0, "jewel socket graph ID of a md, html or svg export")
+	lang := flags.String("lang", string(data.English), "language of a md, html or svg export")
+	width := flags.Int("width", 800, "width of an svg export in pixels")
+
+	var stats statFlag
+	flags.Var(&stats, "stat", "stat to colour svg nodes by as id[:weight[:min]], repeatable")
+	platform := flags.String("platform", string(trade.PC), "trade platform: PC, Xbox or Playstation")
+	league := flags.String("league", "", "trade league, defaults to Standard")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	jewelType, conqueror, err := jewel.parse()
+	if err != nil {
+		return err
+	}
+
+	seed := uint32(jewel.seed)
+
+	var lines []string
+	switch *as {
+	case "pob":
+		text, err := item.PoBText(jewelType, conqueror, seed)
+		if err != nil {
+			return err
+		}
+		lines = []string{text}
+	case "game":
+		text, err := item.GameText(jewelType, conqueror, seed)
+		if err != nil {
+			return err
+		}
+		lines = []string{text}
+	case "trade":
+		if err := item.Validate(jewelType, conqueror, seed); err != nil {
+			return err
+		}
+
+		if lines, err = trade.SearchURLs(jewelType, conqueror, []uint32{seed}, trade.Platform(*platform), *league); err != nil {
+			return err
+		}
+	case "md", "html":
+		if *socket == 0 {
+			return errors.New("-socket is required for a report")
+		}
+
+		report, err := export.NewReport(uint32(*socket), jewelType, conqueror, seed, data.Language(*lang), trade.Platform(*platform), *league)
+		if err != nil {
+			return err
+		}
+
+		text := report.Markdown()
+		if *as == "html" {
+			if text, err = report.HTML(); err != nil {
+				return err
+			}
+		}
+		lines = []string{text}
+	case "svg":
+		if *socket == 0 {
+			return errors.New("-socket is required for an svg")
+		}
+
+		options := export.SVGOptions{
+			Search:   calculator.SearchOptions{Stats: stats},
+			Language: data.Language(*lang),
+			Width:    *width,
+		}
+		return export.WriteSocketSVG(os.Stdout, uint32(*socket), jewelType, conqueror, seed, options)
+	default:
+		return fmt.Errorf("unknown export %q, expected pob, game, trade, md, html or svg", *as)
+	}
+
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+
+	return nil
+}
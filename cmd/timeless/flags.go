@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// jewelFlags are shared by every command working on a single jewel
+type jewelFlags struct {
+	jewel     string
+	conqueror string
+	seed      uint
+}
+
+func (j *jewelFlags) register(flags *flag.FlagSet, withSeed bool) {
+	flags.StringVar(&j.jewel, "jewel", "", "jewel type, by name or number 1-5")
+	flags.StringVar(&j.conqueror, "conqueror", "", "conqueror name")
+	if withSeed {
+		flags.UintVar(&j.seed, "seed", 0, "jewel seed")
+	}
+}
+
+func (j *jewelFlags) parse() (data.JewelType, data.Conqueror, error) {
+	jewelType, err := parseJewelType(j.jewel)
+	if err != nil {
+		return 0, "", err
+	}
+
+	for conqueror := range data.TimelessJewelConquerors[jewelType] {
+		if strings.EqualFold(string(conqueror), j.conqueror) {
+			return jewelType, conqueror, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("conqueror %q does not belong to %s", j.conqueror, jewelType)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
+
+func parseJewelType(value string) (data.JewelType, error) {
+	if number, err := strconv.Atoi(value); err == nil {
+		if _, ok := data.TimelessJewelConquerors[data.JewelType(number)]; ok {
+			return data.JewelType(number), nil
+		}
+	}
+
+	for jewelType := range data.TimelessJewelConquerors {
+		if normalizeName(jewelType.String()) == normalizeName(value) {
+			return jewelType, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown jewel type %q", value)
+}
+
+func parseUints(value string) ([]uint32, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	ids := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", part, err)
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
+
+// statFlag collects repeated -stat id[:weight[:min]] flags
+type statFlag []calculator.StatWeight
+
+func (s *statFlag) String() string {
+	return fmt.Sprint([]calculator.StatWeight(*s))
+}
+
+func (s *statFlag) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return errors.New("expected id[:weight[:min]]")
+	}
+
+	id, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid stat id %q: %w", parts[0], err)
+	}
+
+	stat := calculator.StatWeight{ID: uint32(id), Weight: 1}
+
+	if len(parts) > 1 {
+		if stat.Weight, err = strconv.ParseFloat(parts[1], 64); err != nil {
+			return fmt.Errorf("invalid weight %q: %w", parts[1], err)
+		}
+	}
+
+	if len(parts) > 2 {
+		minimum, err := strconv.ParseUint(parts[2], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid minimum %q: %w", parts[2], err)
+		}
+		stat.Min = uint32(minimum)
+	}
+
+	*s = append(*s, stat)
+	return nil
+}
+
+var passiveTypeNames = map[string]data.PassiveSkillType{
+	"smallattribute": data.SmallAttribute,
+	"smallnormal":    data.SmallNormal,
+	"notable":        data.Notable,
+	"keystone":       data.KeyStone,
+}
+
+func parsePassiveTypes(value string) ([]data.PassiveSkillType, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	types := make([]data.PassiveSkillType, 0)
+	for _, name := range strings.Split(value, ",") {
+		passiveType, ok := passiveTypeNames[normalizeName(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown passive type %q", name)
+		}
+		types = append(types, passiveType)
+	}
+	return types, nil
+}
+
+func newFlagSet(name string, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: timeless %s %s\n", name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
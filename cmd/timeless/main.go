@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"calc": {
+		usage: "calculate a seed on a passive or every passive in a socket",
+		run:   runCalc,
+	},
+	"search": {
+		usage: "reverse search seeds by weighted stats",
+		run:   runSearch,
+	},
+	"stat": {
+		usage: "look up stats by text or ID",
+		run:   runStat,
+	},
+	"explore": {
+		usage: "browse the seeds of a socket interactively",
+		run:   runExplore,
+	},
+	"export": {
+		usage: "export a jewel as item text, trade links, a report or an svg",
+		run:   runExport,
+	},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: timeless [-data <dir>] <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, commands[name].usage)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run timeless <command> -h for the flags of a command")
+}
+
+func main() {
+	flag.Usage = usage
+	dataDir := flag.String("data", "", "directory of game data files to use instead of the embedded data")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	if *dataDir != "" {
+		if err := data.LoadDefaultDir(*dataDir); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := cmd.run(flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
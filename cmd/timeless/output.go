@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	TableFormat  = "table"
+	JSONFormat   = "json"
+	CSVFormat    = "csv"
+	NDJSONFormat = "ndjson"
+)
+
+// output is rendered as rows for table and CSV, and as the structured value for JSON.
+// Commands with a richer CSV or NDJSON export set csv and ndjson.
+type output struct {
+	headers []string
+	rows    [][]string
+	value   any
+
+	csv    func(w io.Writer) error
+	ndjson func(w io.Writer) error
+}
+
+func (o *output) add(cells ...any) {
+	row := make([]string, len(cells))
+	for i, cell := range cells {
+		row[i] = fmt.Sprint(cell)
+	}
+	o.rows = append(o.rows, row)
+}
+
+func (o *output) write(w io.Writer, format string) error {
+	switch format {
+	case TableFormat:
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.Join(o.headers, "\t"))
+		for _, row := range o.rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write table: %w", err)
+		}
+	case CSVFormat:
+		if o.csv != nil {
+			return o.csv(w)
+		}
+
+		writer := csv.NewWriter(w)
+		if err := writer.Write(o.headers); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+		if err := writer.WriteAll(o.rows); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+	case NDJSONFormat:
+		if o.ndjson == nil {
+			return errors.New("ndjson is not supported by this command")
+		}
+		return o.ndjson(w)
+	case JSONFormat:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(o.value); err != nil {
+			return fmt.Errorf("failed to write json: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown format %q, expected table, json, csv or ndjson", format)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/export"
+)
+
+func runSearch(args []string) error {
+	flags := newFlagSet("search", "-jewel <type> -conqueror <name> (-socket <graph id> | -passives <indices>) -stat <id[:weight[:min]]>...")
+
+	var jewel jewelFlags
+	jewel.register(flags, false)
+
+	var stats statFlag
+	flags.Var(&stats, "stat", "stat to search as id[:weight[:min]], repeatable")
+
+	socket := flags.Uint("socket", 0, "jewel socket graph ID, searches every passive in radius")
+	passives := flags.String("passives", "", "comma separated passive skill indices")
+	minTotal := flags.Float64("min-total", 0, "minimum total weight of a seed")
+	types := flags.String("types", "", "comma separated passive types to count: SmallAttribute, SmallNormal, Notable, KeyStone")
+	limit := flags.Int("limit", 20, "number of seeds to print, 0 prints all")
+	lang := flags.String("lang", string(data.English), "language of the stat text in csv output")
+	format := flags.String("format", TableFormat, "output format: table, json, csv or ndjson")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	jewelType, conqueror, err := jewel.parse()
+	if err != nil {
+		return err
+	}
+
+	if len(stats) == 0 {
+		return errors.New("at least one -stat is required")
+	}
+
+	passiveIDs, err := parseUints(*passives)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range passiveIDs {
+		if skill := data.GetPassiveSkillByIndex(id); skill == nil || !data.IsPassiveSkillValidForAlteration(skill) {
+			return fmt.Errorf("passive index %d is not a passive a jewel can transform", id)
+		}
+	}
+
+	if *socket != 0 {
+		passiveIDs = calculator.SocketPassiveIDs(uint32(*socket))
+	}
+
+	if len(passiveIDs) == 0 {
+		return errors.New("one of -socket or -passives is required")
+	}
+
+	passiveTypes, err := parsePassiveTypes(*types)
+	if err != nil {
+		return err
+	}
+
+	options := calculator.SearchOptions{
+		Stats:          stats,
+		MinTotalWeight: *minTotal,
+		PassiveTypes:   passiveTypes,
+	}
+
+	scores := calculator.Search(passiveIDs, jewelType, conqueror, options, nil)
+	if *limit > 0 && len(scores) > *limit {
+		scores = scores[:*limit]
+	}
+
+	out := output{
+		headers: []string{"Seed", "Total"},
+		value:   scores,
+		csv: func(w io.Writer) error {
+			return export.WriteSearchCSV(w, scores, data.Language(*lang))
+		},
+		ndjson: func(w io.Writer) error {
+			return export.WriteNDJSON(w, scores)
+		},
+	}
+
+	for _, stat := range stats {
+		out.headers = append(out.headers, fmt.Sprintf("Stat %d", stat.ID))
+	}
+
+	for _, score := range scores {
+		row := []any{score.Seed, score.Total()}
+		for _, stat := range stats {
+			row = append(row, score.StatCounts[stat.ID])
+		}
+		out.add(row...)
+	}
+
+	return out.write(os.Stdout, *format)
+}
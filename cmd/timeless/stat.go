@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func runStat(args []string) error {
+	flags := newFlagSet("stat", "[-jewel <type>] <query>")
+
+	jewel := flags.String("jewel", "", "only stats the jewel type can roll")
+	lang := flags.String("lang", string(data.English), "language of the stat text")
+	limit := flags.Int("limit", 20, "number of stats to print, 0 prints all")
+	format := flags.String("format", TableFormat, "output format: table, json or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	query := strings.Join(flags.Args(), " ")
+	if query == "" {
+		flags.Usage()
+		return errors.New("a query is required")
+	}
+
+	var jewelType data.JewelType
+	if *jewel != "" {
+		var err error
+		if jewelType, err = parseJewelType(*jewel); err != nil {
+			return err
+		}
+	}
+
+	matches := data.SearchStats(query, jewelType, data.Language(*lang))
+	if *limit > 0 && len(matches) > *limit {
+		matches = matches[:*limit]
+	}
+
+	out := output{
+		headers: []string{"Index", "ID", "Text"},
+		value:   matches,
+	}
+
+	for _, match := range matches {
+		out.add(match.Index, match.ID, match.Text)
+	}
+
+	return out.write(os.Stdout, *format)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func TestCompareSocket(t *testing.T) {
+	augmented := calculator.CompareSocket(testSocket, 10000, data.LethalPride, data.Kaom, data.English)
+	testza.AssertGreater(t, len(augmented), 0)
+
+	bastion := augmented[0]
+	testza.AssertEqual(t, "Aggressive Bastion", bastion.Name)
+	testza.AssertFalse(t, bastion.Replaced)
+	testza.AssertLen(t, bastion.Before, 4)
+	testza.AssertEqual(t, bastion.Before, bastion.After[:4])
+	testza.AssertEqual(t, "20% increased Totem Damage", bastion.After[4].Text)
+	testza.AssertTrue(t, bastion.After[4].Added)
+
+	replaced := calculator.CompareSocket(testSocket, 2000, data.GloriousVanity, data.Xibaqua, data.English)
+	testza.AssertLen(t, replaced, len(augmented))
+
+	for _, node := range replaced {
+		testza.AssertTrue(t, node.Replaced)
+		for _, line := range node.Before {
+			testza.AssertTrue(t, line.Lost)
+		}
+		for _, line := range node.After {
+			testza.AssertFalse(t, line.Lost)
+			testza.AssertNotNil(t, line.StatIndex)
+		}
+	}
+}
+
+func TestCompareUnknownNode(t *testing.T) {
+	testza.AssertEqual(t, calculator.NodeComparison{}, calculator.CompareNode(999999, 2000, data.GloriousVanity, data.Xibaqua, data.English))
+}
@@ -0,0 +1,246 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// DatasetSources are the raw game data files of one game version, each plain or gzipped JSON
+type DatasetSources struct {
+	AlternatePassiveAdditions []byte
+	AlternatePassiveSkills    []byte
+	AlternateTreeVersions     []byte
+	PassiveSkills             []byte
+	Stats                     []byte
+	SkillTree                 []byte
+	PossibleStats             []byte
+}
+
+// EmbeddedSources returns the game data files built into the binary
+func EmbeddedSources() DatasetSources {
+	return DatasetSources{
+		AlternatePassiveAdditions: alternatePassiveAdditionsGz,
+		AlternatePassiveSkills:    alternatePassiveSkillsGz,
+		AlternateTreeVersions:     alternateTreeVersionsGz,
+		PassiveSkills:             passiveSkillsGz,
+		Stats:                     statsGz,
+		SkillTree:                 skillTreeGz,
+		PossibleStats:             possibleStatsGz,
+	}
+}
+
+// Dataset holds the game data of one game version and its lookup indexes.
+// Several datasets may be loaded side by side, language packs are shared between them
+// while stat descriptions loaded with a dataset only apply to it.
+type Dataset struct {
+	AlternatePassiveAdditions []*AlternatePassiveAddition
+	AlternatePassiveSkills    []*AlternatePassiveSkill
+	AlternateTreeVersions     []*AlternateTreeVersion
+	PassiveSkills             []*PassiveSkill
+	Stats                     []*Stat
+	SkillTree                 SkillTree
+	PossibleStats             map[JewelType]map[uint32]int
+
+	idToAlternatePassiveAddition     map[uint32]*AlternatePassiveAddition
+	reverseAlternatePassiveAdditions map[PassiveSkillType]map[uint32][]*AlternatePassiveAddition
+
+	idToAlternatePassiveSkill     map[uint32]*AlternatePassiveSkill
+	reverseAlternatePassiveSkills map[PassiveSkillType]map[uint32][]*AlternatePassiveSkill
+
+	idToAlternateTreeVersion map[uint32]*AlternateTreeVersion
+
+	idToPassiveSkill      map[uint32]*PassiveSkill
+	graphIDToPassiveSkill map[uint32]*PassiveSkill
+
+	idToStat map[uint32]*Stat
+
+	// translations are the English stat descriptions that came with the data, ahead of the embedded ones
+	translations map[string]*Translation
+}
+
+// NewDataset parses a set of game data files and builds their lookup indexes
+func NewDataset(sources DatasetSources) (*Dataset, error) {
+	d := &Dataset{
+		idToAlternatePassiveAddition:     make(map[uint32]*AlternatePassiveAddition),
+		reverseAlternatePassiveAdditions: make(map[PassiveSkillType]map[uint32][]*AlternatePassiveAddition),
+		idToAlternatePassiveSkill:        make(map[uint32]*AlternatePassiveSkill),
+		reverseAlternatePassiveSkills:    make(map[PassiveSkillType]map[uint32][]*AlternatePassiveSkill),
+		idToAlternateTreeVersion:         make(map[uint32]*AlternateTreeVersion),
+		idToPassiveSkill:                 make(map[uint32]*PassiveSkill),
+		graphIDToPassiveSkill:            make(map[uint32]*PassiveSkill),
+		idToStat:                         make(map[uint32]*Stat),
+	}
+
+	files := []struct {
+		name   string
+		source []byte
+		target any
+	}{
+		{"alternate_passive_additions", sources.AlternatePassiveAdditions, &d.AlternatePassiveAdditions},
+		{"alternate_passive_skills", sources.AlternatePassiveSkills, &d.AlternatePassiveSkills},
+		{"alternate_tree_versions", sources.AlternateTreeVersions, &d.AlternateTreeVersions},
+		{"passive_skills", sources.PassiveSkills, &d.PassiveSkills},
+		{"stats", sources.Stats, &d.Stats},
+		{"SkillTree", sources.SkillTree, &d.SkillTree},
+		{"possible_stats", sources.PossibleStats, &d.PossibleStats},
+	}
+
+	for _, file := range files {
+		if len(file.source) == 0 {
+			return nil, fmt.Errorf("%s: missing", file.name)
+		}
+
+		raw, err := maybeUnzip(file.source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.name, err)
+		}
+
+		if err := json.Unmarshal(raw, file.target); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode: %w", file.name, err)
+		}
+	}
+
+	for _, alt := range d.AlternatePassiveAdditions {
+		d.idToAlternatePassiveAddition[alt.Index] = alt
+
+		for _, skillType := range alt.PassiveType {
+			if _, ok := d.reverseAlternatePassiveAdditions[skillType]; !ok {
+				d.reverseAlternatePassiveAdditions[skillType] = make(map[uint32][]*AlternatePassiveAddition)
+			}
+
+			d.reverseAlternatePassiveAdditions[skillType][alt.AlternateTreeVersionsKey] = append(d.reverseAlternatePassiveAdditions[skillType][alt.AlternateTreeVersionsKey], alt)
+		}
+	}
+
+	for _, alt := range d.AlternatePassiveSkills {
+		d.idToAlternatePassiveSkill[alt.Index] = alt
+
+		for _, skillType := range alt.PassiveType {
+			if _, ok := d.reverseAlternatePassiveSkills[skillType]; !ok {
+				d.reverseAlternatePassiveSkills[skillType] = make(map[uint32][]*AlternatePassiveSkill)
+			}
+
+			d.reverseAlternatePassiveSkills[skillType][alt.AlternateTreeVersionsKey] = append(d.reverseAlternatePassiveSkills[skillType][alt.AlternateTreeVersionsKey], alt)
+		}
+	}
+
+	for _, alt := range d.AlternateTreeVersions {
+		d.idToAlternateTreeVersion[alt.Index] = alt
+	}
+
+	for _, skill := range d.PassiveSkills {
+		d.idToPassiveSkill[skill.Index] = skill
+		d.graphIDToPassiveSkill[skill.PassiveSkillGraphID] = skill
+	}
+
+	for _, stat := range d.Stats {
+		d.idToStat[stat.Index] = stat
+	}
+
+	return d, nil
+}
+
+func (d *Dataset) GetApplicableAlternatePassiveAdditions(passiveSkill *PassiveSkill, timelessJewel TimelessJewel) []*AlternatePassiveAddition {
+	return d.reverseAlternatePassiveAdditions[GetPassiveSkillType(passiveSkill)][timelessJewel.AlternateTreeVersion.Index]
+}
+
+func (d *Dataset) GetApplicableAlternatePassiveSkills(passiveSkill *PassiveSkill, timelessJewel TimelessJewel) []*AlternatePassiveSkill {
+	return d.reverseAlternatePassiveSkills[GetPassiveSkillType(passiveSkill)][timelessJewel.AlternateTreeVersion.Index]
+}
+
+func (d *Dataset) GetAlternatePassiveSkillKeyStone(timelessJewel TimelessJewel) *AlternatePassiveSkill {
+	var alternatePassiveSkillKeyStone *AlternatePassiveSkill
+	for _, skill := range d.AlternatePassiveSkills {
+		if skill.AlternateTreeVersionsKey != timelessJewel.AlternateTreeVersion.Index {
+			continue
+		}
+
+		if skill.ConquerorIndex != timelessJewel.TimelessJewelConqueror.Index {
+			continue
+		}
+
+		if skill.ConquerorVersion != timelessJewel.TimelessJewelConqueror.Version {
+			continue
+		}
+
+		alternatePassiveSkillKeyStone = skill
+		break
+	}
+
+	if alternatePassiveSkillKeyStone == nil {
+		return nil
+	}
+
+	hasApplicablePassives := false
+	for _, passiveType := range alternatePassiveSkillKeyStone.PassiveType {
+		if passiveType == KeyStone {
+			hasApplicablePassives = true
+			break
+		}
+	}
+
+	if !hasApplicablePassives {
+		return nil
+	}
+
+	return alternatePassiveSkillKeyStone
+}
+
+func (d *Dataset) GetPassiveSkillByIndex(index uint32) *PassiveSkill {
+	return d.idToPassiveSkill[index]
+}
+
+func (d *Dataset) GetPassiveSkillByGraphID(graphID uint32) *PassiveSkill {
+	return d.graphIDToPassiveSkill[graphID]
+}
+
+func (d *Dataset) GetStatByIndex(index uint32) *Stat {
+	return d.idToStat[index]
+}
+
+func (d *Dataset) GetAlternatePassiveSkillByIndex(index uint32) *AlternatePassiveSkill {
+	return d.idToAlternatePassiveSkill[index]
+}
+
+func (d *Dataset) GetAlternatePassiveAdditionByIndex(index uint32) *AlternatePassiveAddition {
+	return d.idToAlternatePassiveAddition[index]
+}
+
+func (d *Dataset) GetAlternateTreeVersionIndex(index uint32) *AlternateTreeVersion {
+	return d.idToAlternateTreeVersion[index]
+}
+
+func (d *Dataset) GetApplicablePassives() []*PassiveSkill {
+	applicable := make([]*PassiveSkill, 0)
+	for _, skill := range d.PassiveSkills {
+		if skill.Name == "" {
+			continue
+		}
+
+		if skill.IsJewelSocket {
+			continue
+		}
+
+		if node, ok := d.SkillTree.Nodes[strconv.Itoa(int(skill.PassiveSkillGraphID))]; ok {
+			if node.AscendancyName != nil {
+				continue
+			}
+
+			if node.IsProxy != nil && *node.IsProxy {
+				continue
+			}
+
+			if node.IsBlighted != nil && *node.IsBlighted {
+				continue
+			}
+
+			if node.IsMastery != nil && *node.IsMastery {
+				continue
+			}
+
+			applicable = append(applicable, skill)
+		}
+	}
+	return applicable
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// DatasetFileNames are the file names of each dataset source without their .json or .json.gz extension,
+// matching the go-pob-data exports
+var DatasetFileNames = struct {
+	AlternatePassiveAdditions string
+	AlternatePassiveSkills    string
+	AlternateTreeVersions     string
+	PassiveSkills             string
+	Stats                     string
+	SkillTree                 string
+	PossibleStats             string
+}{
+	AlternatePassiveAdditions: "alternate_passive_additions",
+	AlternatePassiveSkills:    "alternate_passive_skills",
+	AlternateTreeVersions:     "alternate_tree_versions",
+	PassiveSkills:             "passive_skills",
+	Stats:                     "stats",
+	SkillTree:                 "SkillTree",
+	PossibleStats:             "possible_stats",
+}
+
+// DatasetReaders are readers of the dataset sources, each plain or gzipped JSON.
+// Nil readers fall back to the embedded file.
+type DatasetReaders struct {
+	AlternatePassiveAdditions io.Reader
+	AlternatePassiveSkills    io.Reader
+	AlternateTreeVersions     io.Reader
+	PassiveSkills             io.Reader
+	Stats                     io.Reader
+	SkillTree                 io.Reader
+	PossibleStats             io.Reader
+}
+
+// sourceFields pairs the fields of sources with their file names
+func (s *DatasetSources) sourceFields() map[string]*[]byte {
+	return map[string]*[]byte{
+		DatasetFileNames.AlternatePassiveAdditions: &s.AlternatePassiveAdditions,
+		DatasetFileNames.AlternatePassiveSkills:    &s.AlternatePassiveSkills,
+		DatasetFileNames.AlternateTreeVersions:     &s.AlternateTreeVersions,
+		DatasetFileNames.PassiveSkills:             &s.PassiveSkills,
+		DatasetFileNames.Stats:                     &s.Stats,
+		DatasetFileNames.SkillTree:                 &s.SkillTree,
+		DatasetFileNames.PossibleStats:             &s.PossibleStats,
+	}
+}
+
+// withEmbedded fills every missing source with the embedded file
+func (s DatasetSources) withEmbedded() DatasetSources {
+	embedded := EmbeddedSources()
+	embeddedFields := embedded.sourceFields()
+
+	for name, field := range s.sourceFields() {
+		if len(*field) == 0 {
+			*field = *embeddedFields[name]
+		}
+	}
+
+	return s
+}
+
+// LoadDatasetFS loads a dataset from the files at the root of fsys, stored as .json or .json.gz.
+// Missing files fall back to the embedded ones, so a go-pob-data export without possible_stats still loads.
+// Stat descriptions found next to the data translate the stats of this dataset only, ahead of the embedded English ones.
+func LoadDatasetFS(fsys fs.FS) (*Dataset, error) {
+	var sources DatasetSources
+	for name, field := range sources.sourceFields() {
+		file, err := readJSONFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		*field = file
+	}
+
+	dataset, err := NewDataset(sources.withEmbedded())
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := readTranslationFiles(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataset.translations, err = parseTranslations(translations...); err != nil {
+		return nil, err
+	}
+
+	return dataset, nil
+}
+
+// LoadDatasetDir loads a dataset from a directory, see LoadDatasetFS
+func LoadDatasetDir(dir string) (*Dataset, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to open data directory: %w", err)
+	}
+
+	return LoadDatasetFS(os.DirFS(dir))
+}
+
+// ReadDataset loads a dataset from readers, nil readers fall back to the embedded files
+func ReadDataset(readers DatasetReaders) (*Dataset, error) {
+	var sources DatasetSources
+	fields := sources.sourceFields()
+
+	for name, reader := range map[string]io.Reader{
+		DatasetFileNames.AlternatePassiveAdditions: readers.AlternatePassiveAdditions,
+		DatasetFileNames.AlternatePassiveSkills:    readers.AlternatePassiveSkills,
+		DatasetFileNames.AlternateTreeVersions:     readers.AlternateTreeVersions,
+		DatasetFileNames.PassiveSkills:             readers.PassiveSkills,
+		DatasetFileNames.Stats:                     readers.Stats,
+		DatasetFileNames.SkillTree:                 readers.SkillTree,
+		DatasetFileNames.PossibleStats:             readers.PossibleStats,
+	} {
+		if reader == nil {
+			continue
+		}
+
+		file, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		*fields[name] = file
+	}
+
+	return NewDataset(sources.withEmbedded())
+}
+
+// SetDefault replaces the dataset behind the package level lookups and variables.
+// Call it at startup, the default skill tree and calculator engine drop what they built from the previous dataset.
+func SetDefault(d *Dataset) error {
+	return setDefault(d)
+}
+
+// LoadDefaultDir loads a data directory and makes it the default dataset
+func LoadDefaultDir(dir string) error {
+	dataset, err := LoadDatasetDir(dir)
+	if err != nil {
+		return err
+	}
+
+	return SetDefault(dataset)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
+	"fmt"
 	"io"
 
 	_ "embed"
@@ -11,46 +12,38 @@ import (
 
 //go:embed alternate_passive_additions.json.gz
 var alternatePassiveAdditionsGz []byte
-var AlternatePassiveAdditions []*AlternatePassiveAddition
-
-var (
-	idToAlternatePassiveAddition     = make(map[uint32]*AlternatePassiveAddition)
-	reverseAlternatePassiveAdditions = make(map[PassiveSkillType]map[uint32][]*AlternatePassiveAddition)
-)
 
 //go:embed alternate_passive_skills.json.gz
 var alternatePassiveSkillsGz []byte
-var AlternatePassiveSkills []*AlternatePassiveSkill
-
-var (
-	idToAlternatePassiveSkill     = make(map[uint32]*AlternatePassiveSkill)
-	reverseAlternatePassiveSkills = make(map[PassiveSkillType]map[uint32][]*AlternatePassiveSkill)
-)
 
 //go:embed alternate_tree_versions.json.gz
 var alternateTreeVersionsGz []byte
-var AlternateTreeVersions []*AlternateTreeVersion
-
-var idToAlternateTreeVersion = make(map[uint32]*AlternateTreeVersion)
 
 //go:embed passive_skills.json.gz
 var passiveSkillsGz []byte
-var PassiveSkills []*PassiveSkill
-
-var idToPassiveSkill = make(map[uint32]*PassiveSkill)
 
 //go:embed stats.json.gz
 var statsGz []byte
-var Stats []*Stat
-
-var idToStat = make(map[uint32]*Stat)
 
 //go:embed SkillTree.json.gz
 var skillTreeGz []byte
 
+//go:embed possible_stats.json.gz
+var possibleStatsGz []byte
+
+// The data of the default dataset, kept for callers that predate Dataset
 var (
+	AlternatePassiveAdditions []*AlternatePassiveAddition
+	AlternatePassiveSkills    []*AlternatePassiveSkill
+	AlternateTreeVersions     []*AlternateTreeVersion
+	PassiveSkills             []*PassiveSkill
+	Stats                     []*Stat
+
 	SkillTreeJSON []byte
 	SkillTreeData SkillTree
+
+	PossibleStatsJSON []byte
+	PossibleStats     map[JewelType]map[uint32]int
 )
 
 //go:embed stat_descriptions.json.gz
@@ -65,62 +58,46 @@ var PassiveSkillStatTranslationsJSON []byte
 var passiveSkillAuraStatTranslationsGz []byte
 var PassiveSkillAuraStatTranslationsJSON []byte
 
-//go:embed possible_stats.json.gz
-var possibleStatsGz []byte
-var PossibleStatsJSON []byte
-
-func init() {
-	AlternatePassiveAdditions = unzipJSONTo[[]*AlternatePassiveAddition](alternatePassiveAdditionsGz)
-
-	for _, alt := range AlternatePassiveAdditions {
-		idToAlternatePassiveAddition[alt.Index] = alt
-
-		for _, skillType := range alt.PassiveType {
-			if _, ok := reverseAlternatePassiveAdditions[skillType]; !ok {
-				reverseAlternatePassiveAdditions[skillType] = make(map[uint32][]*AlternatePassiveAddition)
-			}
+var defaultDataset *Dataset
 
-			reverseAlternatePassiveAdditions[skillType][alt.AlternateTreeVersionsKey] = append(reverseAlternatePassiveAdditions[skillType][alt.AlternateTreeVersionsKey], alt)
-		}
-	}
-
-	AlternatePassiveSkills = unzipJSONTo[[]*AlternatePassiveSkill](alternatePassiveSkillsGz)
-
-	for _, alt := range AlternatePassiveSkills {
-		idToAlternatePassiveSkill[alt.Index] = alt
-
-		for _, skillType := range alt.PassiveType {
-			if _, ok := reverseAlternatePassiveSkills[skillType]; !ok {
-				reverseAlternatePassiveSkills[skillType] = make(map[uint32][]*AlternatePassiveSkill)
-			}
+// Default returns the dataset used by the package level lookups
+func Default() *Dataset {
+	return defaultDataset
+}
 
-			reverseAlternatePassiveSkills[skillType][alt.AlternateTreeVersionsKey] = append(reverseAlternatePassiveSkills[skillType][alt.AlternateTreeVersionsKey], alt)
-		}
+func setDefault(d *Dataset) error {
+	skillTreeJSON, err := json.Marshal(d.SkillTree)
+	if err != nil {
+		return fmt.Errorf("failed to encode skill tree: %w", err)
 	}
 
-	AlternateTreeVersions = unzipJSONTo[[]*AlternateTreeVersion](alternateTreeVersionsGz)
-
-	for _, alt := range AlternateTreeVersions {
-		idToAlternateTreeVersion[alt.Index] = alt
+	possibleStatsJSON, err := json.Marshal(d.PossibleStats)
+	if err != nil {
+		return fmt.Errorf("failed to encode possible stats: %w", err)
 	}
 
-	PassiveSkills = unzipJSONTo[[]*PassiveSkill](passiveSkillsGz)
+	defaultDataset = d
 
-	for _, skill := range PassiveSkills {
-		idToPassiveSkill[skill.Index] = skill
-	}
+	AlternatePassiveAdditions = d.AlternatePassiveAdditions
+	AlternatePassiveSkills = d.AlternatePassiveSkills
+	AlternateTreeVersions = d.AlternateTreeVersions
+	PassiveSkills = d.PassiveSkills
+	Stats = d.Stats
+	SkillTreeData = d.SkillTree
+	SkillTreeJSON = skillTreeJSON
+	PossibleStats = d.PossibleStats
+	PossibleStatsJSON = possibleStatsJSON
 
-	Stats = unzipJSONTo[[]*Stat](statsGz)
+	return nil
+}
 
-	for _, stat := range Stats {
-		idToStat[stat.Index] = stat
+func init() {
+	dataset, err := NewDataset(EmbeddedSources())
+	if err != nil {
+		panic(err)
 	}
 
-	SkillTreeData = unzipJSONTo[SkillTree](skillTreeGz)
-
-	var err error
-	SkillTreeJSON, err = json.Marshal(SkillTreeData)
-	if err != nil {
+	if err := setDefault(dataset); err != nil {
 		panic(err)
 	}
 
@@ -128,16 +105,7 @@ func init() {
 	PassiveSkillStatTranslationsJSON = unzipTo(passiveSkillStatTranslationsGz)
 	PassiveSkillAuraStatTranslationsJSON = unzipTo(passiveSkillAuraStatTranslationsGz)
 
-	PossibleStatsJSON = unzipTo(possibleStatsGz)
-}
-
-func unzipJSONTo[T any](data []byte) T {
-	out := new(T)
-	if err := json.Unmarshal(unzipTo(data), &out); err != nil {
-		panic(err)
-	}
-
-	return *out
+	initEnglish()
 }
 
 func unzipTo(data []byte) []byte {
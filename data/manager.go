@@ -1,9 +1,7 @@
 package data
 
-import "strconv"
-
 func GetApplicableAlternatePassiveAdditions(passiveSkill *PassiveSkill, timelessJewel TimelessJewel) []*AlternatePassiveAddition {
-	return reverseAlternatePassiveAdditions[GetPassiveSkillType(passiveSkill)][timelessJewel.AlternateTreeVersion.Index]
+	return Default().GetApplicableAlternatePassiveAdditions(passiveSkill, timelessJewel)
 }
 
 func GetPassiveSkillType(passiveSkill *PassiveSkill) PassiveSkillType {
@@ -21,45 +19,11 @@ func GetPassiveSkillType(passiveSkill *PassiveSkill) PassiveSkillType {
 }
 
 func GetAlternatePassiveSkillKeyStone(timelessJewel TimelessJewel) *AlternatePassiveSkill {
-	var alternatePassiveSkillKeyStone *AlternatePassiveSkill
-	for _, skill := range AlternatePassiveSkills {
-		if skill.AlternateTreeVersionsKey != timelessJewel.AlternateTreeVersion.Index {
-			continue
-		}
-
-		if skill.ConquerorIndex != timelessJewel.TimelessJewelConqueror.Index {
-			continue
-		}
-
-		if skill.ConquerorVersion != timelessJewel.TimelessJewelConqueror.Version {
-			continue
-		}
-
-		alternatePassiveSkillKeyStone = skill
-		break
-	}
-
-	if alternatePassiveSkillKeyStone == nil {
-		return nil
-	}
-
-	hasApplicablePassives := false
-	for _, passiveType := range alternatePassiveSkillKeyStone.PassiveType {
-		if passiveType == KeyStone {
-			hasApplicablePassives = true
-			break
-		}
-	}
-
-	if !hasApplicablePassives {
-		return nil
-	}
-
-	return alternatePassiveSkillKeyStone
+	return Default().GetAlternatePassiveSkillKeyStone(timelessJewel)
 }
 
 func GetApplicableAlternatePassiveSkills(passiveSkill *PassiveSkill, timelessJewel TimelessJewel) []*AlternatePassiveSkill {
-	return reverseAlternatePassiveSkills[GetPassiveSkillType(passiveSkill)][timelessJewel.AlternateTreeVersion.Index]
+	return Default().GetApplicableAlternatePassiveSkills(passiveSkill, timelessJewel)
 }
 
 func IsSmallAttribute(stat uint32) bool {
@@ -76,55 +40,29 @@ func IsPassiveSkillValidForAlteration(passiveSkill *PassiveSkill) bool {
 }
 
 func GetPassiveSkillByIndex(index uint32) *PassiveSkill {
-	return idToPassiveSkill[index]
+	return Default().GetPassiveSkillByIndex(index)
+}
+
+func GetPassiveSkillByGraphID(graphID uint32) *PassiveSkill {
+	return Default().GetPassiveSkillByGraphID(graphID)
 }
 
 func GetStatByIndex(index uint32) *Stat {
-	return idToStat[index]
+	return Default().GetStatByIndex(index)
 }
 
 func GetAlternatePassiveSkillByIndex(index uint32) *AlternatePassiveSkill {
-	return idToAlternatePassiveSkill[index]
+	return Default().GetAlternatePassiveSkillByIndex(index)
 }
 
 func GetAlternatePassiveAdditionByIndex(index uint32) *AlternatePassiveAddition {
-	return idToAlternatePassiveAddition[index]
+	return Default().GetAlternatePassiveAdditionByIndex(index)
 }
 
 func GetAlternateTreeVersionIndex(index uint32) *AlternateTreeVersion {
-	return idToAlternateTreeVersion[index]
+	return Default().GetAlternateTreeVersionIndex(index)
 }
 
 func GetApplicablePassives() []*PassiveSkill {
-	applicable := make([]*PassiveSkill, 0)
-	for _, skill := range PassiveSkills {
-		if skill.Name == "" {
-			continue
-		}
-
-		if skill.IsJewelSocket {
-			continue
-		}
-
-		if node, ok := SkillTreeData.Nodes[strconv.Itoa(int(skill.PassiveSkillGraphID))]; ok {
-			if node.AscendancyName != nil {
-				continue
-			}
-
-			if node.IsProxy != nil && *node.IsProxy {
-				continue
-			}
-
-			if node.IsBlighted != nil && *node.IsBlighted {
-				continue
-			}
-
-			if node.IsMastery != nil && *node.IsMastery {
-				continue
-			}
-
-			applicable = append(applicable, skill)
-		}
-	}
-	return applicable
+	return Default().GetApplicablePassives()
 }
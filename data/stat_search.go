@@ -0,0 +1,108 @@
+package data
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+)
+
+type StatMatch struct {
+	Index    uint32
+	ID       string
+	Text     string
+	Distance int
+}
+
+// SearchStats ranks the possible stats of a jewel type in the default dataset
+func SearchStats(query string, jewelType JewelType, lang Language) []StatMatch {
+	return Default().SearchStats(query, jewelType, lang)
+}
+
+// SearchStats ranks the possible stats of a jewel type by how well their translated text or ID matches the query.
+// Passing a zero jewel type searches the possible stats of every jewel.
+func (d *Dataset) SearchStats(query string, jewelType JewelType, lang Language) []StatMatch {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []StatMatch{}
+	}
+
+	candidates := make(map[uint32]int)
+	for possibleType, stats := range d.PossibleStats {
+		if jewelType != 0 && possibleType != jewelType {
+			continue
+		}
+
+		for index, count := range stats {
+			candidates[index] += count
+		}
+	}
+
+	lowerQuery := strings.ToLower(query)
+
+	type rankedMatch struct {
+		StatMatch
+		tier  int
+		count int
+	}
+
+	ranked := make([]rankedMatch, 0)
+	for index, count := range candidates {
+		stat := d.GetStatByIndex(index)
+		if stat == nil {
+			continue
+		}
+
+		text := d.TranslateStatTemplate(lang, index)
+
+		tier := -1
+		distance := 0
+		if strings.Contains(strings.ToLower(text), lowerQuery) {
+			tier, distance = 0, len(text)-len(query)
+		} else if strings.Contains(strings.ToLower(stat.ID), lowerQuery) {
+			tier, distance = 1, len(stat.ID)-len(query)
+		} else if d := fuzzy.RankMatchNormalizedFold(query, text); d >= 0 {
+			tier, distance = 2, d
+		} else if d := fuzzy.RankMatchNormalizedFold(query, stat.ID); d >= 0 {
+			tier, distance = 3, d
+		}
+
+		if tier < 0 {
+			continue
+		}
+
+		ranked = append(ranked, rankedMatch{
+			StatMatch: StatMatch{
+				Index:    index,
+				ID:       stat.ID,
+				Text:     text,
+				Distance: distance,
+			},
+			tier:  tier,
+			count: count,
+		})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].tier != ranked[j].tier {
+			return ranked[i].tier < ranked[j].tier
+		}
+
+		if ranked[i].Distance != ranked[j].Distance {
+			return ranked[i].Distance < ranked[j].Distance
+		}
+
+		if ranked[i].count != ranked[j].count {
+			return ranked[i].count > ranked[j].count
+		}
+
+		return ranked[i].Index < ranked[j].Index
+	})
+
+	matches := make([]StatMatch, len(ranked))
+	for i, match := range ranked {
+		matches[i] = match.StatMatch
+	}
+
+	return matches
+}
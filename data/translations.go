@@ -0,0 +1,476 @@
+package data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Language string
+
+const English = Language("en")
+
+// TranslationFileNames are the stat description files of a language, in lookup priority order
+var TranslationFileNames = []string{
+	"stat_descriptions",
+	"passive_skill_stat_descriptions",
+	"passive_skill_aura_stat_descriptions",
+}
+
+// NamesFileName holds a JSON object mapping English jewel and conqueror names to their localized form
+const NamesFileName = "names"
+
+type TranslationCondition struct {
+	Min     *int64 `json:"min,omitempty"`
+	Max     *int64 `json:"max,omitempty"`
+	Negated bool   `json:"negated"`
+}
+
+func (c TranslationCondition) Matches(value int64) bool {
+	matches := true
+	if c.Min != nil && value < *c.Min {
+		matches = false
+	}
+
+	if c.Max != nil && value > *c.Max {
+		matches = false
+	}
+
+	if c.Negated {
+		return !matches
+	}
+
+	return matches
+}
+
+// IndexHandlers accepts both the object and the nested array forms used by the exports
+type IndexHandlers []string
+
+func (h *IndexHandlers) UnmarshalJSON(b []byte) error {
+	var byName map[string]json.RawMessage
+	if err := json.Unmarshal(b, &byName); err == nil {
+		for name := range byName {
+			*h = append(*h, name)
+		}
+		return nil
+	}
+
+	var nested [][]string
+	if err := json.Unmarshal(b, &nested); err != nil {
+		return fmt.Errorf("failed to decode index handlers: %w", err)
+	}
+
+	if len(nested) > 0 {
+		*h = nested[0]
+	}
+
+	return nil
+}
+
+type TranslationEntry struct {
+	Conditions    []TranslationCondition `json:"conditions,omitempty"`
+	IndexHandlers IndexHandlers          `json:"index_handlers,omitempty"`
+	String        string                 `json:"string"`
+}
+
+type Translation struct {
+	IDs    []string           `json:"ids"`
+	List   []TranslationEntry `json:"list"`
+	Hidden bool               `json:"hidden,omitempty"`
+}
+
+type TranslationFile struct {
+	Descriptors []Translation `json:"descriptors"`
+	Includes    []string      `json:"includes"`
+}
+
+type languagePack struct {
+	stats map[string]*Translation
+	names map[string]string
+}
+
+var (
+	languagePacksMu sync.RWMutex
+	languagePacks   = make(map[Language]*languagePack)
+)
+
+func initEnglish() {
+	files := [][]byte{StatTranslationsJSON, PassiveSkillStatTranslationsJSON, PassiveSkillAuraStatTranslationsJSON}
+	if err := LoadTranslations(English, files...); err != nil {
+		panic(err)
+	}
+}
+
+// LoadTranslations registers stat description files for a language, in lookup priority order.
+// Files may be plain or gzipped JSON. Loading into an existing language only fills in missing stats.
+func LoadTranslations(lang Language, files ...[]byte) error {
+	stats, err := parseTranslations(files...)
+	if err != nil {
+		return err
+	}
+
+	pack := getOrCreatePack(lang)
+
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+
+	for id, translation := range stats {
+		if _, ok := pack.stats[id]; !ok {
+			pack.stats[id] = translation
+		}
+	}
+
+	return nil
+}
+
+// parseTranslations indexes stat description files by stat ID, earlier files win
+func parseTranslations(files ...[]byte) (map[string]*Translation, error) {
+	stats := make(map[string]*Translation)
+	for i, file := range files {
+		raw, err := maybeUnzip(file)
+		if err != nil {
+			return nil, fmt.Errorf("translation file %d: %w", i, err)
+		}
+
+		var parsed TranslationFile
+		if err := json.Unmarshal(raw, &parsed); err != nil {
+			return nil, fmt.Errorf("translation file %d: %w", i, err)
+		}
+
+		for j := range parsed.Descriptors {
+			for _, id := range parsed.Descriptors[j].IDs {
+				if _, ok := stats[id]; !ok {
+					stats[id] = &parsed.Descriptors[j]
+				}
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// readTranslationFiles reads every known translation file present at the root of fsys
+func readTranslationFiles(fsys fs.FS) ([][]byte, error) {
+	files := make([][]byte, 0, len(TranslationFileNames))
+	for _, name := range TranslationFileNames {
+		file, err := readJSONFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// LoadNames registers localized jewel and conqueror names for a language
+func LoadNames(lang Language, file []byte) error {
+	raw, err := maybeUnzip(file)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string)
+	if err := json.Unmarshal(raw, &names); err != nil {
+		return fmt.Errorf("failed to decode names: %w", err)
+	}
+
+	pack := getOrCreatePack(lang)
+
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+
+	for english, localized := range names {
+		pack.names[english] = localized
+	}
+
+	return nil
+}
+
+// LoadTranslationsFS loads every known translation file present at the root of fsys.
+// Each file may be stored as .json or .json.gz, missing files are skipped.
+func LoadTranslationsFS(lang Language, fsys fs.FS) error {
+	files, err := readTranslationFiles(fsys)
+	if err != nil {
+		return err
+	}
+
+	if len(files) > 0 {
+		if err := LoadTranslations(lang, files...); err != nil {
+			return err
+		}
+	}
+
+	names, err := readJSONFile(fsys, NamesFileName)
+	if err != nil {
+		return err
+	}
+
+	if names != nil {
+		return LoadNames(lang, names)
+	}
+
+	return nil
+}
+
+// LoadTranslationsFromDir loads a language from a go-pob-data stat_translations/<lang> directory
+func LoadTranslationsFromDir(lang Language, dir string) error {
+	return LoadTranslationsFS(lang, os.DirFS(dir))
+}
+
+// Languages returns all currently loaded languages
+func Languages() []Language {
+	languagePacksMu.RLock()
+	defer languagePacksMu.RUnlock()
+
+	languages := make([]Language, 0, len(languagePacks))
+	for lang := range languagePacks {
+		languages = append(languages, lang)
+	}
+
+	return languages
+}
+
+func getOrCreatePack(lang Language) *languagePack {
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+
+	pack, ok := languagePacks[lang]
+	if !ok {
+		pack = &languagePack{
+			stats: make(map[string]*Translation),
+			names: make(map[string]string),
+		}
+		languagePacks[lang] = pack
+	}
+
+	return pack
+}
+
+func lookupTranslation(lang Language, statID string) *Translation {
+	languagePacksMu.RLock()
+	defer languagePacksMu.RUnlock()
+
+	if pack, ok := languagePacks[lang]; ok {
+		return pack.stats[statID]
+	}
+
+	return nil
+}
+
+// lookupTranslation prefers the requested language, then the stat descriptions loaded with the dataset, then English
+func (d *Dataset) lookupTranslation(lang Language, statID string) *Translation {
+	if lang != English {
+		if translation := lookupTranslation(lang, statID); translation != nil {
+			return translation
+		}
+	}
+
+	if translation, ok := d.translations[statID]; ok {
+		return translation
+	}
+
+	return lookupTranslation(English, statID)
+}
+
+func lookupName(lang Language, english string) string {
+	languagePacksMu.RLock()
+	defer languagePacksMu.RUnlock()
+
+	if pack, ok := languagePacks[lang]; ok {
+		if name, ok := pack.names[english]; ok && name != "" {
+			return name
+		}
+	}
+
+	return english
+}
+
+// Localize returns the jewel name in the requested language, falling back to English
+func (t JewelType) Localize(lang Language) string {
+	return lookupName(lang, t.String())
+}
+
+// Localize returns the conqueror name in the requested language, falling back to English
+func (c Conqueror) Localize(lang Language) string {
+	return lookupName(lang, string(c))
+}
+
+var indexHandlers = map[string]float64{
+	"negate":                                   -1,
+	"times_twenty":                             1.0 / 20,
+	"canonical_stat":                           1,
+	"per_minute_to_per_second":                 60,
+	"milliseconds_to_seconds":                  1000,
+	"display_indexable_support":                1,
+	"divide_by_one_hundred":                    100,
+	"milliseconds_to_seconds_2dp_if_required":  1000,
+	"deciseconds_to_seconds":                   10,
+	"old_leech_percent":                        1,
+	"old_leech_permyriad":                      10000,
+	"times_one_point_five":                     1 / 1.5,
+	"30%_of_value":                             100.0 / 30,
+	"divide_by_one_thousand":                   1000,
+	"divide_by_twelve":                         12,
+	"divide_by_six":                            6,
+	"per_minute_to_per_second_2dp_if_required": 60,
+	"60%_of_value":                             100.0 / 60,
+	"double":                                   1.0 / 2,
+	"negate_and_double":                        1.0 / -2,
+	"multiply_by_four":                         1.0 / 4,
+	"per_minute_to_per_second_0dp":             60,
+	"milliseconds_to_seconds_0dp":              1000,
+	"mod_value_to_item_class":                  1,
+	"milliseconds_to_seconds_2dp":              1000,
+	"multiplicative_damage_modifier":           1,
+	"divide_by_one_hundred_2dp":                100,
+	"per_minute_to_per_second_1dp":             60,
+	"divide_by_one_hundred_2dp_if_required":    100,
+	"divide_by_ten_1dp_if_required":            10,
+	"milliseconds_to_seconds_1dp":              1000,
+	"divide_by_fifty":                          50,
+	"per_minute_to_per_second_2dp":             60,
+	"divide_by_ten_0dp":                        10,
+	"divide_by_one_hundred_and_negate":         -100,
+	"tree_expansion_jewel_passive":             1,
+	"passive_hash":                             1,
+	"divide_by_ten_1dp":                        10,
+	"affliction_reward_type":                   1,
+	"divide_by_five":                           5,
+	"metamorphosis_reward_description":         1,
+	"divide_by_two_0dp":                        2,
+	"divide_by_fifteen_0dp":                    15,
+	"divide_by_three":                          3,
+	"divide_by_twenty_then_double_0dp":         10,
+	"divide_by_four":                           4,
+}
+
+var (
+	formattedPlaceholder = regexp.MustCompile(`\{0:(\+?)d?\}`)
+	anyPlaceholder       = regexp.MustCompile(`\{\d?(?::\+?d?)?\}`)
+)
+
+// FormatTranslation renders a single stat value with the first matching entry of a translation
+func FormatTranslation(translation *Translation, value int64) (string, bool) {
+	for _, entry := range translation.List {
+		if len(entry.Conditions) > 0 && !entry.Conditions[0].Matches(value) {
+			continue
+		}
+
+		final := float64(value)
+		for _, handler := range entry.IndexHandlers {
+			if divisor, ok := indexHandlers[handler]; ok {
+				final /= divisor
+			}
+		}
+
+		formatted := strconv.FormatFloat(math.Round(final*100)/100, 'f', -1, 64)
+
+		text := formattedPlaceholder.ReplaceAllStringFunc(entry.String, func(match string) string {
+			if strings.Contains(match, "+") && final >= 0 {
+				return "+" + formatted
+			}
+			return formatted
+		})
+
+		return strings.Replace(text, "{0}", formatted, 1), true
+	}
+
+	return "", false
+}
+
+// TranslateStat renders a rolled stat of the default dataset in the requested language
+func TranslateStat(lang Language, statIndex uint32, roll uint32) string {
+	return Default().TranslateStat(lang, statIndex, roll)
+}
+
+// TranslateStatTemplate renders a stat of the default dataset with # in place of its value
+func TranslateStatTemplate(lang Language, statIndex uint32) string {
+	return Default().TranslateStatTemplate(lang, statIndex)
+}
+
+// TranslateStat renders a rolled stat in the requested language, falling back to English and then the stat ID
+func (d *Dataset) TranslateStat(lang Language, statIndex uint32, roll uint32) string {
+	stat := d.GetStatByIndex(statIndex)
+	if stat == nil {
+		return ""
+	}
+
+	if translation := d.lookupTranslation(lang, stat.ID); translation != nil {
+		if text, ok := FormatTranslation(translation, int64(roll)); ok {
+			return text
+		}
+	}
+
+	return stat.ID
+}
+
+// TranslateStatTemplate renders a stat with # in place of its value
+func (d *Dataset) TranslateStatTemplate(lang Language, statIndex uint32) string {
+	stat := d.GetStatByIndex(statIndex)
+	if stat == nil {
+		return ""
+	}
+
+	text := stat.Text
+	if text == "" {
+		text = stat.ID
+	}
+
+	if translation := d.lookupTranslation(lang, stat.ID); translation != nil && len(translation.List) > 0 {
+		text = translation.List[0].String
+		text = anyPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+			if strings.Contains(match, "+") {
+				return "+#"
+			}
+			return "#"
+		})
+	}
+
+	return text
+}
+
+func readJSONFile(fsys fs.FS, name string) ([]byte, error) {
+	for _, candidate := range []string{name + ".json.gz", name + ".json"} {
+		file, err := fs.ReadFile(fsys, candidate)
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", candidate, err)
+		}
+	}
+
+	return nil, nil
+}
+
+func maybeUnzip(file []byte) ([]byte, error) {
+	if len(file) < 2 || file[0] != 0x1f || file[1] != 0x8b {
+		return file, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip: %w", err)
+	}
+
+	all, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress: %w", err)
+	}
+
+	return all, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+func TestDatasetSideBySide(t *testing.T) {
+	sources := data.EmbeddedSources()
+
+	// Plain JSON loads the same as the gzipped embedded files
+	reader, err := gzip.NewReader(bytes.NewReader(sources.PassiveSkills))
+	testza.AssertNoError(t, err)
+	sources.PassiveSkills, err = io.ReadAll(reader)
+	testza.AssertNoError(t, err)
+
+	dataset, err := data.NewDataset(sources)
+	testza.AssertNoError(t, err)
+	testza.AssertFalse(t, dataset == data.Default())
+
+	testza.AssertEqual(t, len(data.PassiveSkills), len(dataset.PassiveSkills))
+	testza.AssertEqual(t, data.GetPassiveSkillByIndex(1210).Name, dataset.GetPassiveSkillByIndex(1210).Name)
+	testza.AssertEqual(t, data.GetStatByIndex(25).ID, dataset.GetStatByIndex(25).ID)
+	testza.AssertEqual(t, len(data.GetApplicablePassives()), len(dataset.GetApplicablePassives()))
+	testza.AssertEqual(t, data.TranslateStat(data.English, 25, 12), dataset.TranslateStat(data.English, 25, 12))
+
+	// Datasets do not share their data
+	dataset.GetPassiveSkillByIndex(1210).Name = "Changed"
+	testza.AssertNotEqual(t, "Changed", data.GetPassiveSkillByIndex(1210).Name)
+
+	sources.Stats = nil
+	_, err = data.NewDataset(sources)
+	testza.AssertNotNil(t, err)
+}
+
+func TestLoadDatasetFS(t *testing.T) {
+	embedded := data.EmbeddedSources()
+
+	reader, err := gzip.NewReader(bytes.NewReader(embedded.Stats))
+	testza.AssertNoError(t, err)
+	stats, err := io.ReadAll(reader)
+	testza.AssertNoError(t, err)
+
+	stats = bytes.Replace(stats, []byte(`"spell_damage_+%"`), []byte(`"patched_spell_damage_+%"`), 1)
+
+	fsys := fstest.MapFS{
+		"stats.json":             &fstest.MapFile{Data: stats},
+		"passive_skills.json.gz": &fstest.MapFile{Data: embedded.PassiveSkills},
+	}
+
+	dataset, err := data.LoadDatasetFS(fsys)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "patched_spell_damage_+%", dataset.GetStatByIndex(25).ID)
+	testza.AssertEqual(t, "spell_damage_+%", data.GetStatByIndex(25).ID)
+
+	// Files missing from the directory come from the embedded data
+	testza.AssertEqual(t, len(data.AlternatePassiveSkills), len(dataset.AlternatePassiveSkills))
+
+	dataset, err = data.ReadDataset(data.DatasetReaders{Stats: bytes.NewReader(stats)})
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "patched_spell_damage_+%", dataset.GetStatByIndex(25).ID)
+
+	_, err = data.ReadDataset(data.DatasetReaders{Stats: strings.NewReader("{")})
+	testza.AssertNotNil(t, err)
+
+	original := data.Default()
+	testza.AssertNoError(t, data.SetDefault(dataset))
+	testza.AssertEqual(t, "patched_spell_damage_+%", data.GetStatByIndex(25).ID)
+	testza.AssertNoError(t, data.SetDefault(original))
+	testza.AssertEqual(t, "spell_damage_+%", data.GetStatByIndex(25).ID)
+}
+
+func TestLoadDatasetTranslations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"stat_descriptions.json": &fstest.MapFile{Data: []byte(`{"descriptors":[{"ids":["spell_damage_+%"],"list":[{"string":"{0}% increased Patched Spell Damage"}]}]}`)},
+	}
+
+	dataset, err := data.LoadDatasetFS(fsys)
+	testza.AssertNoError(t, err)
+
+	// The descriptions only apply to the dataset they were loaded with
+	testza.AssertEqual(t, "12% increased Patched Spell Damage", dataset.TranslateStat(data.English, 25, 12))
+	testza.AssertEqual(t, "12% increased Spell Damage", data.TranslateStat(data.English, 25, 12))
+	testza.AssertEqual(t, "12% increased Spell Damage", data.Default().TranslateStat(data.English, 25, 12))
+
+	graph := tree.SkillGraph()
+	testza.AssertEqual(t, uint32(8), calculator.ReverseSearch([]uint32{1210}, []uint32{25}, data.GloriousVanity, data.Xibaqua, nil)[1001][1210][25])
+
+	// Move the stat elsewhere in the new dataset, a stale cache would still find it
+	for _, skill := range dataset.AlternatePassiveSkills {
+		for i, key := range skill.StatsKeys {
+			if key == 25 {
+				skill.StatsKeys[i] = 26
+			}
+		}
+	}
+	for _, addition := range dataset.AlternatePassiveAdditions {
+		for i, key := range addition.StatsKeys {
+			if key == 25 {
+				addition.StatsKeys[i] = 26
+			}
+		}
+	}
+
+	original := data.Default()
+	testza.AssertNoError(t, data.SetDefault(dataset))
+	defer func() {
+		testza.AssertNoError(t, data.SetDefault(original))
+	}()
+
+	testza.AssertEqual(t, "12% increased Patched Spell Damage", data.TranslateStat(data.English, 25, 12))
+
+	// The default tree and engine follow the new dataset instead of serving what they built before
+	testza.AssertFalse(t, graph == tree.SkillGraph())
+	testza.AssertTrue(t, tree.Default().Dataset() == dataset)
+	testza.AssertLen(t, calculator.ReverseSearch([]uint32{1210}, []uint32{25}, data.GloriousVanity, data.Xibaqua, nil)[1001], 0)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func TestSearchDevotion(t *testing.T) {
+	passives := calculator.SocketPassiveIDs(testSocket)
+
+	summaries := calculator.SearchDevotion(passives, data.Avarius, calculator.DevotionOptions{MinDevotion: 50}, nil)
+	testza.AssertGreater(t, len(summaries), 0)
+
+	for i, summary := range summaries {
+		testza.AssertTrue(t, summary.Devotion >= 50)
+		if i > 0 {
+			testza.AssertTrue(t, summaries[i-1].Devotion >= summary.Devotion)
+		}
+	}
+
+	best := summaries[0]
+	testza.AssertEqual(t, best, calculator.SummarizeDevotion(passives, best.Seed, data.Avarius))
+
+	withNotables := calculator.SearchDevotion(passives, data.Avarius, calculator.DevotionOptions{MinTemplarNotables: 1}, nil)
+	testza.AssertGreater(t, len(withNotables), 0)
+
+	best = withNotables[0]
+
+	required := calculator.DevotionOptions{Notables: []uint32{best.TemplarNotables[0].AlternatePassive}}
+	testza.AssertTrue(t, required.Matches(best))
+	testza.AssertFalse(t, calculator.DevotionOptions{MinDevotion: best.Devotion + 1}.Matches(best))
+}
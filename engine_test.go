@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func TestEngineIsolated(t *testing.T) {
+	dataset, err := data.NewDataset(data.EmbeddedSources())
+	testza.AssertNoError(t, err)
+
+	engine := calculator.NewEngine(dataset)
+	testza.AssertTrue(t, engine.Dataset() == dataset)
+	testza.AssertTrue(t, calculator.DefaultEngine().Dataset() == data.Default())
+
+	expected := calculator.Calculate(1210, 1001, data.GloriousVanity, data.Xibaqua)
+	actual := engine.Calculate(1210, 1001, data.GloriousVanity, data.Xibaqua)
+	testza.AssertEqual(t, expected.AlternatePassiveSkill.Index, actual.AlternatePassiveSkill.Index)
+	testza.AssertEqual(t, expected.StatRolls, actual.StatRolls)
+
+	// Results point into the dataset of the engine that calculated them
+	testza.AssertTrue(t, actual.AlternatePassiveSkill == dataset.GetAlternatePassiveSkillByIndex(actual.AlternatePassiveSkill.Index))
+	testza.AssertFalse(t, actual.AlternatePassiveSkill == expected.AlternatePassiveSkill)
+
+	testza.AssertEqual(t, calculator.SocketPassiveIDs(testSocket), engine.SocketPassiveIDs(testSocket))
+
+	options := calculator.SearchOptions{Stats: []calculator.StatWeight{{ID: 25, Weight: 1}}}
+	passives := engine.SocketPassiveIDs(testSocket)
+	scores := engine.Search(passives, data.GloriousVanity, data.Xibaqua, options, nil)
+	testza.AssertEqual(t, calculator.Search(passives, data.GloriousVanity, data.Xibaqua, options, nil)[0], scores[0])
+
+	engine.ClearCache()
+	testza.AssertEqual(t, scores[0], engine.Search(passives, data.GloriousVanity, data.Xibaqua, options, nil)[0])
+}
+
+func TestEngineTree(t *testing.T) {
+	dataset, err := data.NewDataset(data.EmbeddedSources())
+	testza.AssertNoError(t, err)
+
+	// Drop the first passive in the radius from the engine's skill tree only
+	removed := data.GetPassiveSkillByIndex(calculator.SocketPassiveIDs(testSocket)[0])
+	delete(dataset.SkillTree.Nodes, strconv.Itoa(int(removed.PassiveSkillGraphID)))
+
+	engine := calculator.NewEngine(dataset)
+	testza.AssertTrue(t, engine.Tree().Dataset() == dataset)
+	testza.AssertTrue(t, calculator.DefaultEngine().Tree().Dataset() == data.Default())
+
+	testza.AssertNotContains(t, engine.SocketPassiveIDs(testSocket), removed.Index)
+	testza.AssertContains(t, calculator.SocketPassiveIDs(testSocket), removed.Index)
+
+	plan, err := engine.PlanAllocation(nil, 1, testSocket, 1001, data.GloriousVanity, data.Xibaqua, []calculator.StatWeight{{ID: 25, Weight: 1}}, 0)
+	testza.AssertNoError(t, err)
+	testza.AssertNotContains(t, plan.Nodes, removed.PassiveSkillGraphID)
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+var SearchCSVHeader = []string{"Seed", "Total", "Passive", "Node", "Name", "Stat", "Stat ID", "Roll", "Text"}
+
+var SocketCSVHeader = []string{"Seed", "Passive", "Node", "Name", "New Name", "Change", "Stat", "Roll", "Text"}
+
+func formatUint[T ~uint32](value T) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func sortedKeys(stats map[uint32]uint32) []uint32 {
+	keys := make([]uint32, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// WriteSearchCSV writes one row per seed, passive and matched stat of scored search results
+func WriteSearchCSV(w io.Writer, scores []calculator.SeedScore, lang data.Language) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(SearchCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	for _, score := range scores {
+		total := strconv.FormatFloat(score.Total(), 'f', -1, 64)
+		for _, node := range score.Nodes {
+			skill := data.GetPassiveSkillByIndex(node.Passive)
+			if skill == nil {
+				continue
+			}
+
+			for _, statIndex := range sortedKeys(node.Stats) {
+				roll := node.Stats[statIndex]
+
+				stat := data.GetStatByIndex(statIndex)
+				statID := ""
+				if stat != nil {
+					statID = stat.ID
+				}
+
+				row := []string{
+					formatUint(score.Seed),
+					total,
+					formatUint(node.Passive),
+					formatUint(skill.PassiveSkillGraphID),
+					skill.Name,
+					formatUint(statIndex),
+					statID,
+					formatUint(roll),
+					data.TranslateStat(lang, statIndex, roll),
+				}
+
+				if err := writer.Write(row); err != nil {
+					return fmt.Errorf("failed to write csv: %w", err)
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return nil
+}
+
+// WriteSocketCSV writes one row per passive and stat line of a socket calculation, lost stats included
+func WriteSocketCSV(w io.Writer, seed uint32, comparisons []calculator.NodeComparison) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(SocketCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	write := func(comparison calculator.NodeComparison, change string, line calculator.StatLine) error {
+		statIndex := ""
+		if line.StatIndex != nil {
+			statIndex = formatUint(*line.StatIndex)
+		}
+
+		row := []string{
+			formatUint(seed),
+			formatUint(comparison.Passive),
+			formatUint(comparison.GraphID),
+			comparison.Name,
+			comparison.NewName,
+			change,
+			statIndex,
+			formatUint(line.Roll),
+			line.Text,
+		}
+
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+		return nil
+	}
+
+	for _, comparison := range comparisons {
+		for _, line := range comparison.Before {
+			if line.Lost {
+				if err := write(comparison, "lost", line); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, line := range comparison.After {
+			change := "kept"
+			if line.Added {
+				change = "added"
+			}
+
+			if err := write(comparison, change, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteNDJSON writes each value as a single line of JSON
+func WriteNDJSON[T any](w io.Writer, values []T) error {
+	encoder := json.NewEncoder(w)
+	for i, value := range values {
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Errorf("failed to write line %d: %w", i, err)
+		}
+	}
+	return nil
+}
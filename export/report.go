@@ -0,0 +1,156 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+	"github.com/BlazesRus/timeless-jewels/trade"
+)
+
+// Report describes a single seed in a socket for sharing
+type Report struct {
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Seed      uint32
+	Socket    uint32
+	Language  data.Language
+
+	JewelName     string
+	ConquerorName string
+	TradeURLs     []string
+	PoBText       string
+	// Nodes only holds passives the jewel changes
+	Nodes []calculator.NodeComparison
+}
+
+// NewReport calculates a seed in a socket and gathers its trade links and item text
+func NewReport(socketGraphID uint32, jewelType data.JewelType, conqueror data.Conqueror, seed uint32, lang data.Language, platform trade.Platform, league string) (*Report, error) {
+	pobText, err := item.PoBText(jewelType, conqueror, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := trade.SearchURLs(jewelType, conqueror, []uint32{seed}, platform, league)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		JewelType:     jewelType,
+		Conqueror:     conqueror,
+		Seed:          seed,
+		Socket:        socketGraphID,
+		Language:      lang,
+		JewelName:     jewelType.Localize(lang),
+		ConquerorName: conqueror.Localize(lang),
+		TradeURLs:     urls,
+		PoBText:       pobText,
+	}
+
+	for _, node := range calculator.CompareSocket(socketGraphID, seed, jewelType, conqueror, lang) {
+		if node.Replaced || len(node.After) > len(node.Before) {
+			report.Nodes = append(report.Nodes, node)
+		}
+	}
+
+	return report, nil
+}
+
+// Title names the jewel, seed and socket of the report
+func (r *Report) Title() string {
+	return fmt.Sprintf("%s %d (%s) in socket %d", r.JewelName, r.Seed, r.ConquerorName, r.Socket)
+}
+
+// markdownEscaper escapes the characters stat text may contain that Markdown would interpret
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "#", `\#`)
+
+// Markdown renders the report with lost stats struck through and added stats in bold
+func (r *Report) Markdown() string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "# %s\n\n", markdownEscaper.Replace(r.Title()))
+
+	for i, url := range r.TradeURLs {
+		fmt.Fprintf(&out, "- [Trade search %d](%s)\n", i+1, url)
+	}
+
+	out.WriteString("\n## Changes\n")
+	for _, node := range r.Nodes {
+		heading := markdownEscaper.Replace(node.Name)
+		if node.Replaced {
+			heading += " → " + markdownEscaper.Replace(node.NewName)
+		}
+		fmt.Fprintf(&out, "\n### %s\n\n", heading)
+
+		for _, line := range node.Before {
+			if line.Lost {
+				fmt.Fprintf(&out, "- ~~%s~~\n", markdownEscaper.Replace(line.Text))
+			}
+		}
+
+		for _, line := range node.After {
+			if line.Added {
+				fmt.Fprintf(&out, "- **%s**\n", markdownEscaper.Replace(line.Text))
+			} else {
+				fmt.Fprintf(&out, "- %s\n", markdownEscaper.Replace(line.Text))
+			}
+		}
+	}
+
+	fmt.Fprintf(&out, "\n## Path of Building\n\n```\n%s\n```\n", r.PoBText)
+
+	return out.String()
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{ .Language }}">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; }
+.lost { color: #a33; text-decoration: line-through; }
+.added { color: #3a3; font-weight: bold; }
+pre { background: #eee; padding: 1rem; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<ul>
+{{- range $i, $url := .TradeURLs }}
+<li><a href="{{ $url }}">Trade search {{ inc $i }}</a></li>
+{{- end }}
+</ul>
+<h2>Changes</h2>
+{{- range .Nodes }}
+<h3>{{ .Name }}{{ if .Replaced }} → {{ .NewName }}{{ end }}</h3>
+<ul>
+{{- range .Before }}{{ if .Lost }}
+<li class="lost">{{ .Text }}</li>
+{{- end }}{{ end }}
+{{- range .After }}
+<li{{ if .Added }} class="added"{{ end }}>{{ .Text }}</li>
+{{- end }}
+</ul>
+{{- end }}
+<h2>Path of Building</h2>
+<pre>{{ .PoBText }}</pre>
+</body>
+</html>
+`))
+
+// HTML renders the report as a standalone page
+func (r *Report) HTML() (string, error) {
+	var out bytes.Buffer
+	if err := reportTemplate.Execute(&out, r); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,282 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+const (
+	svgBackground = "#080c11"
+	svgConnection = "#524518"
+	svgRadius     = "#ad2b2b"
+	svgOutside    = "#2b2f36"
+	svgUntouched  = "#5c5f66"
+	svgReplaced   = "#c8a040"
+	svgAugmented  = "#6a8caf"
+)
+
+// SVGOptions controls how a socket is rendered
+type SVGOptions struct {
+	// Search weights the stats nodes are coloured by, nodes are coloured by kind when empty
+	Search   calculator.SearchOptions
+	Language data.Language
+	// Margin is the amount of tree drawn around the jewel radius, in tree units
+	Margin float64
+	// Width is the rendered width in pixels
+	Width int
+}
+
+type svgNode struct {
+	node     data.Node
+	position tree.Point
+	inRadius bool
+}
+
+// WriteSocketSVG draws the radius of a jewel in a socket with every transformed node annotated.
+// Nodes are coloured by their score against the search stats, from orange to green.
+func WriteSocketSVG(w io.Writer, socketGraphID uint32, jewelType data.JewelType, conqueror data.Conqueror, seed uint32, options SVGOptions) error {
+	if err := item.Validate(jewelType, conqueror, seed); err != nil {
+		return err
+	}
+
+	skillTree := tree.Default()
+
+	socket, ok := skillTree.Node(socketGraphID)
+	if !ok {
+		return fmt.Errorf("unknown socket %d", socketGraphID)
+	}
+
+	center, ok := skillTree.NodePosition(socket)
+	if !ok {
+		return fmt.Errorf("socket %d has no position", socketGraphID)
+	}
+
+	if options.Margin <= 0 {
+		options.Margin = 300
+	}
+
+	if options.Width <= 0 {
+		options.Width = 800
+	}
+
+	if options.Language == "" {
+		options.Language = data.English
+	}
+
+	comparisons := make(map[uint32]calculator.NodeComparison)
+	for _, comparison := range calculator.CompareSocket(socketGraphID, seed, jewelType, conqueror, options.Language) {
+		comparisons[comparison.GraphID] = comparison
+	}
+
+	weights := make(map[uint32]float64)
+	maxWeight := 0.0
+	score := calculator.ScoreSocket(socketGraphID, seed, jewelType, conqueror, options.Search)
+	for _, node := range score.Nodes {
+		skill := data.GetPassiveSkillByIndex(node.Passive)
+		if skill == nil {
+			continue
+		}
+
+		weights[skill.PassiveSkillGraphID] = node.Weight
+		maxWeight = math.Max(maxWeight, node.Weight)
+	}
+
+	extent := tree.BaseJewelRadius + options.Margin
+	nodes := make(map[uint32]svgNode)
+	for _, graphID := range skillTree.NodesInRadius(socketGraphID, extent) {
+		node, _ := skillTree.Node(graphID)
+		position, _ := skillTree.NodePosition(node)
+		nodes[graphID] = svgNode{
+			node:     node,
+			position: position,
+			inRadius: position.Distance(center) < tree.BaseJewelRadius,
+		}
+	}
+
+	// NodesInRadius skips the socket itself when it is a cluster socket
+	if _, ok := nodes[socketGraphID]; !ok {
+		nodes[socketGraphID] = svgNode{node: socket, position: center, inRadius: true}
+	}
+
+	ids := make([]uint32, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sortUint32s(ids)
+
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%s %s %s %s">`+"\n",
+		options.Width, options.Width, formatFloat(center.X-extent), formatFloat(center.Y-extent), formatFloat(extent*2), formatFloat(extent*2))
+
+	title := fmt.Sprintf("%s %d (%s) in socket %d", jewelType.Localize(options.Language), seed, conqueror.Localize(options.Language), socketGraphID)
+	fmt.Fprintf(out, "<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintf(out, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+		formatFloat(center.X-extent), formatFloat(center.Y-extent), formatFloat(extent*2), formatFloat(extent*2), svgBackground)
+
+	fmt.Fprintf(out, `<g stroke="%s" stroke-width="12" fill="none">`+"\n", svgConnection)
+	for _, id := range ids {
+		from := nodes[id]
+		for _, target := range from.node.Out {
+			targetID, err := strconv.ParseUint(target, 10, 32)
+			if err != nil {
+				continue
+			}
+
+			to, ok := nodes[uint32(targetID)]
+			if !ok || isMastery(from.node) || isMastery(to.node) {
+				continue
+			}
+
+			fmt.Fprintf(out, `<path d="%s"/>`+"\n", connectionPath(skillTree, from, to))
+		}
+	}
+	fmt.Fprintln(out, "</g>")
+
+	fmt.Fprintf(out, `<circle cx="%s" cy="%s" r="%d" stroke="%s" stroke-width="8" fill="none"/>`+"\n",
+		formatFloat(center.X), formatFloat(center.Y), tree.BaseJewelRadius, svgRadius)
+
+	for _, id := range ids {
+		n := nodes[id]
+		if isMastery(n.node) {
+			continue
+		}
+
+		fill := svgOutside
+		if n.inRadius {
+			fill = svgUntouched
+		}
+
+		comparison, transformed := comparisons[id]
+		weight, scored := weights[id]
+		switch {
+		case transformed && scored && maxWeight > 0:
+			fill = scoreColour(weight / maxWeight)
+		case transformed && comparison.Replaced:
+			fill = svgReplaced
+		case transformed:
+			fill = svgAugmented
+		}
+
+		fmt.Fprintf(out, `<g><circle cx="%s" cy="%s" r="%d" fill="%s"/>`,
+			formatFloat(n.position.X), formatFloat(n.position.Y), nodeRadius(n.node), fill)
+
+		if transformed {
+			fmt.Fprintf(out, "<title>%s</title>", html.EscapeString(comparisonText(comparison)))
+		} else if n.node.Name != nil {
+			fmt.Fprintf(out, "<title>%s</title>", html.EscapeString(*n.node.Name))
+		}
+
+		fmt.Fprintln(out, "</g>")
+
+		if transformed && (comparison.Replaced || isNotable(n.node)) {
+			label := comparison.NewName
+			if scored {
+				label += " (" + formatFloat(weight) + ")"
+			}
+
+			fmt.Fprintf(out, `<text x="%s" y="%s" fill="#ffffff" font-family="sans-serif" font-size="40" text-anchor="middle">%s</text>`+"\n",
+				formatFloat(n.position.X), formatFloat(n.position.Y-float64(nodeRadius(n.node))-12), html.EscapeString(label))
+		}
+	}
+
+	fmt.Fprintf(out, `<text x="%s" y="%s" fill="#ffffff" font-family="sans-serif" font-size="70">%s</text>`+"\n",
+		formatFloat(center.X-extent+40), formatFloat(center.Y-extent+100), html.EscapeString(title))
+
+	fmt.Fprintln(out, "</svg>")
+
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write svg: %w", err)
+	}
+
+	return nil
+}
+
+// connectionPath draws nodes on the same orbit of a group as an arc and every other connection as a line
+func connectionPath(skillTree *tree.Tree, from svgNode, to svgNode) string {
+	start := formatFloat(from.position.X) + " " + formatFloat(from.position.Y)
+	end := formatFloat(to.position.X) + " " + formatFloat(to.position.Y)
+
+	if from.node.Group == nil || to.node.Group == nil || from.node.Orbit == nil || to.node.Orbit == nil ||
+		*from.node.Group != *to.node.Group || *from.node.Orbit != *to.node.Orbit || *from.node.Orbit == 0 {
+		return "M " + start + " L " + end
+	}
+
+	group, _ := skillTree.GroupCenter(from.node)
+	radius := formatFloat(skillTree.OrbitRadius(*from.node.Orbit))
+
+	// Always take the short way around, clockwise on screen when the target is clockwise of the start
+	cross := (from.position.X-group.X)*(to.position.Y-group.Y) - (from.position.Y-group.Y)*(to.position.X-group.X)
+	sweep := "0"
+	if cross > 0 {
+		sweep = "1"
+	}
+
+	return "M " + start + " A " + radius + " " + radius + " 0 0 " + sweep + " " + end
+}
+
+// scoreColour fades from orange for the lowest scores to green for the best node of the seed
+func scoreColour(ratio float64) string {
+	return fmt.Sprintf("hsl(%d, 80%%, 50%%)", int(30+90*ratio))
+}
+
+func comparisonText(comparison calculator.NodeComparison) string {
+	lines := []string{comparison.Name}
+	if comparison.Replaced {
+		lines[0] += " → " + comparison.NewName
+	}
+
+	for _, line := range comparison.Before {
+		if line.Lost {
+			lines = append(lines, "- "+line.Text)
+		}
+	}
+
+	for _, line := range comparison.After {
+		if line.Added {
+			lines = append(lines, "+ "+line.Text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func nodeRadius(node data.Node) int {
+	switch {
+	case node.IsKeystone != nil && *node.IsKeystone:
+		return 60
+	case isNotable(node), node.IsJewelSocket != nil && *node.IsJewelSocket:
+		return 42
+	default:
+		return 26
+	}
+}
+
+func isNotable(node data.Node) bool {
+	return node.IsNotable != nil && *node.IsNotable
+}
+
+func isMastery(node data.Node) bool {
+	return node.IsMastery != nil && *node.IsMastery
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func sortUint32s(values []uint32) {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+}
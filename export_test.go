@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/export"
+	"github.com/BlazesRus/timeless-jewels/trade"
+)
+
+func TestExportSearchCSV(t *testing.T) {
+	options := calculator.SearchOptions{Stats: []calculator.StatWeight{{ID: 25, Weight: 1}}}
+	scores := calculator.Search(calculator.SocketPassiveIDs(testSocket), data.GloriousVanity, data.Xibaqua, options, nil)[:3]
+
+	var out bytes.Buffer
+	testza.AssertNoError(t, export.WriteSearchCSV(&out, scores, data.English))
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	testza.AssertNoError(t, err)
+
+	expected := 0
+	for _, score := range scores {
+		for _, node := range score.Nodes {
+			expected += len(node.Stats)
+		}
+	}
+
+	testza.AssertEqual(t, export.SearchCSVHeader, rows[0])
+	testza.AssertLen(t, rows, expected+1)
+
+	var lines bytes.Buffer
+	testza.AssertNoError(t, export.WriteNDJSON(&lines, scores))
+	testza.AssertEqual(t, len(scores), strings.Count(lines.String(), "\n"))
+}
+
+func TestExportSocketCSV(t *testing.T) {
+	comparisons := calculator.CompareSocket(testSocket, 1001, data.GloriousVanity, data.Xibaqua, data.English)
+
+	var out bytes.Buffer
+	testza.AssertNoError(t, export.WriteSocketCSV(&out, 1001, comparisons))
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, export.SocketCSVHeader, rows[0])
+
+	for _, row := range rows[1:] {
+		testza.AssertEqual(t, "1001", row[0])
+		testza.AssertContains(t, []string{"lost", "kept", "added"}, row[5])
+	}
+}
+
+func TestExportReport(t *testing.T) {
+	report, err := export.NewReport(testSocket, data.GloriousVanity, data.Xibaqua, 1001, data.English, trade.PC, "")
+	testza.AssertNoError(t, err)
+	testza.AssertGreater(t, len(report.Nodes), 0)
+
+	markdown := report.Markdown()
+	testza.AssertContains(t, markdown, "Glorious Vanity 1001 (Xibaqua)")
+	testza.AssertContains(t, markdown, report.TradeURLs[0])
+	testza.AssertContains(t, markdown, report.Nodes[0].NewName)
+
+	html, err := report.HTML()
+	testza.AssertNoError(t, err)
+	testza.AssertContains(t, html, "<title>Glorious Vanity 1001 (Xibaqua) in socket 26725</title>")
+
+	_, err = export.NewReport(testSocket, data.GloriousVanity, data.Xibaqua, 1, data.English, trade.PC, "")
+	testza.AssertNotNil(t, err)
+}
+
+func TestExportSocketSVG(t *testing.T) {
+	options := export.SVGOptions{Search: calculator.SearchOptions{Stats: []calculator.StatWeight{{ID: 25, Weight: 1}}}}
+
+	var out bytes.Buffer
+	testza.AssertNoError(t, export.WriteSocketSVG(&out, testSocket, data.GloriousVanity, data.Xibaqua, 2000, options))
+
+	var parsed struct {
+		XMLName xml.Name `xml:"svg"`
+		Title   string   `xml:"title"`
+	}
+	testza.AssertNoError(t, xml.Unmarshal(out.Bytes(), &parsed))
+	testza.AssertEqual(t, "Glorious Vanity 2000 (Xibaqua) in socket 26725", parsed.Title)
+
+	for _, comparison := range calculator.CompareSocket(testSocket, 2000, data.GloriousVanity, data.Xibaqua, data.English) {
+		if comparison.Replaced {
+			testza.AssertContains(t, out.String(), comparison.NewName)
+		}
+	}
+
+	testza.AssertNotNil(t, export.WriteSocketSVG(&out, testSocket, data.GloriousVanity, data.Xibaqua, 1, options))
+}
@@ -1,7 +1,118 @@
 /* eslint-disable */
 export declare namespace calculator {
+  interface Allocation {
+    Allocated?: Record<number, boolean>;
+    PathCosts?: Record<number, number>;
+    SocketCost: number;
+  }
+  interface DevotionOptions {
+    MinDevotion: number;
+    MinTemplarNotables: number;
+    Notables?: Array<number>;
+    Matches(summary: calculator.DevotionSummary): boolean;
+  }
+  interface DevotionSummary {
+    Seed: number;
+    Conqueror: string;
+    Devotion: number;
+    TemplarNotables?: Array<calculator.TemplarNotable>;
+  }
+  interface Jewel {
+    JewelType: number;
+    Conqueror: string;
+    Seed: number;
+  }
+  interface JewelScore {
+    SeedScore: calculator.SeedScore;
+    JewelType: number;
+    Conqueror: string;
+    Normalized: number;
+    Total(): number;
+    ValuePerPoint(): number;
+  }
+  interface NodeComparison {
+    Passive: number;
+    GraphID: number;
+    Name: string;
+    NewName: string;
+    Replaced: boolean;
+    Before?: Array<calculator.StatLine>;
+    After?: Array<calculator.StatLine>;
+  }
+  interface NodeDiff {
+    Passive: number;
+    GraphID: number;
+    Name: string;
+    Jewels?: Array<calculator.NodeComparison>;
+    Differs: boolean;
+  }
+  interface NodeScore {
+    Passive: number;
+    Stats?: Record<number, number>;
+    Weight: number;
+    Allocated: boolean;
+    PathCost: number;
+  }
+  interface Plan {
+    Seed: number;
+    Socket: number;
+    Nodes?: Array<number>;
+    Points: number;
+    Weight: number;
+    Captured?: Array<calculator.NodeScore>;
+  }
+  interface SearchOptions {
+    Stats?: Array<calculator.StatWeight>;
+    MinTotalWeight: number;
+    Allocation?: calculator.Allocation;
+    UnallocatedWeight: number;
+    PassiveTypes?: Array<number>;
+    Matches(score: calculator.SeedScore): boolean;
+  }
+  interface SeedComparison {
+    Socket: number;
+    Jewels?: Array<calculator.Jewel>;
+    Nodes?: Array<calculator.NodeDiff>;
+    Scores?: Array<calculator.SeedScore>;
+    Deltas?: Array<number>;
+  }
+  interface SeedScore {
+    Seed: number;
+    Weight: number;
+    Potential: number;
+    PotentialCost: number;
+    PointCost: number;
+    StatCounts?: Record<number, number>;
+    Nodes?: Array<calculator.NodeScore>;
+    Total(): number;
+    ValuePerPoint(): number;
+  }
+  interface StatLine {
+    Text: string;
+    StatIndex?: number;
+    Roll: number;
+    Lost: boolean;
+    Added: boolean;
+  }
+  interface StatWeight {
+    ID: number;
+    Weight: number;
+    Min: number;
+  }
+  interface TemplarNotable {
+    Passive: number;
+    AlternatePassive: number;
+    Name: string;
+  }
   function Calculate(passiveID: number, seed: number, timelessJewelType: number, conqueror: string): data.AlternatePassiveSkillInformation;
+  function CompareSeeds(socketGraphID: number, jewels?: Array<calculator.Jewel>, options: calculator.SearchOptions, lang: string): [(calculator.SeedComparison | undefined), Error];
+  function CompareSocket(socketGraphID: number, seed: number, timelessJewelType: number, conqueror: string, lang: string): (Array<calculator.NodeComparison> | undefined);
+  function FilterPassiveTypes(passiveIDs?: Array<number>, types?: Array<number>): (Array<number> | undefined);
+  function PlanAllocation(allocatedGraphIDs?: Array<number>, classID: number, socketGraphID: number, seed: number, timelessJewelType: number, conqueror: string, stats?: Array<calculator.StatWeight>, budget: number): [(calculator.Plan | undefined), Error];
   function ReverseSearch(passiveIDs?: Array<number>, statIDs?: Array<number>, timelessJewelType: number, conqueror: string, updates: (arg1: number) => Promise<void>): Promise<(Record<number, Record<number, Record<number, number> | undefined> | undefined> | undefined)>;
+  function SearchAllJewels(passiveIDs?: Array<number>, options: calculator.SearchOptions, updates: (arg1: number, arg2: string, arg3: number) => Promise<void>): Promise<(Array<calculator.JewelScore> | undefined)>;
+  function SearchDevotion(passiveIDs?: Array<number>, conqueror: string, options: calculator.DevotionOptions, updates: (arg1: number) => Promise<void>): Promise<(Array<calculator.DevotionSummary> | undefined)>;
+  function SummarizeDevotion(passiveIDs?: Array<number>, seed: number, conqueror: string): calculator.DevotionSummary;
 }
 export declare namespace data {
   interface AlternatePassiveAddition {
@@ -69,6 +180,12 @@ export declare namespace data {
     Text: string;
     Category?: number;
   }
+  interface StatMatch {
+    Index: number;
+    ID: string;
+    Text: string;
+    Distance: number;
+  }
   interface TimelessJewelConqueror {
     Index: number;
     Version: number;
@@ -79,8 +196,10 @@ export declare namespace data {
   function GetStatByIndex(index: number): (data.Stat | undefined);
   const PassiveSkillAuraStatTranslationsJSON: string;
   const PassiveSkillStatTranslationsJSON: string;
+  const PassiveSkillTypes: Record<number, string> | undefined;
   const PassiveSkills: Array<data.PassiveSkill | undefined> | undefined;
   const PossibleStats: string;
+  function SearchStats(query: string, jewelType: number, lang: string): (Array<data.StatMatch> | undefined);
   const SkillTree: string;
   const StatTranslationsJSON: string;
   const TimelessJewelConquerors: Record<number, Record<string, data.TimelessJewelConqueror | undefined> | undefined> | undefined;
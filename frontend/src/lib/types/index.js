@@ -5,7 +5,14 @@ export let data;
 export const initializeCrystalline = () => {
   calculator = {
     Calculate: globalThis["go"]["timeless-jewels"]["calculator"]["Calculate"],
+    CompareSeeds: globalThis["go"]["timeless-jewels"]["calculator"]["CompareSeeds"],
+    CompareSocket: globalThis["go"]["timeless-jewels"]["calculator"]["CompareSocket"],
+    FilterPassiveTypes: globalThis["go"]["timeless-jewels"]["calculator"]["FilterPassiveTypes"],
+    PlanAllocation: globalThis["go"]["timeless-jewels"]["calculator"]["PlanAllocation"],
     ReverseSearch: globalThis["go"]["timeless-jewels"]["calculator"]["ReverseSearch"],
+    SearchAllJewels: globalThis["go"]["timeless-jewels"]["calculator"]["SearchAllJewels"],
+    SearchDevotion: globalThis["go"]["timeless-jewels"]["calculator"]["SearchDevotion"],
+    SummarizeDevotion: globalThis["go"]["timeless-jewels"]["calculator"]["SummarizeDevotion"],
   }
   data = {
     GetAlternatePassiveAdditionByIndex: globalThis["go"]["timeless-jewels"]["data"]["GetAlternatePassiveAdditionByIndex"],
@@ -14,8 +21,10 @@ export const initializeCrystalline = () => {
     GetStatByIndex: globalThis["go"]["timeless-jewels"]["data"]["GetStatByIndex"],
     PassiveSkillAuraStatTranslationsJSON: globalThis["go"]["timeless-jewels"]["data"]["PassiveSkillAuraStatTranslationsJSON"],
     PassiveSkillStatTranslationsJSON: globalThis["go"]["timeless-jewels"]["data"]["PassiveSkillStatTranslationsJSON"],
+    PassiveSkillTypes: globalThis["go"]["timeless-jewels"]["data"]["PassiveSkillTypes"],
     PassiveSkills: globalThis["go"]["timeless-jewels"]["data"]["PassiveSkills"],
     PossibleStats: globalThis["go"]["timeless-jewels"]["data"]["PossibleStats"],
+    SearchStats: globalThis["go"]["timeless-jewels"]["data"]["SearchStats"],
     SkillTree: globalThis["go"]["timeless-jewels"]["data"]["SkillTree"],
     StatTranslationsJSON: globalThis["go"]["timeless-jewels"]["data"]["StatTranslationsJSON"],
     TimelessJewelConquerors: globalThis["go"]["timeless-jewels"]["data"]["TimelessJewelConquerors"],
@@ -3,23 +3,23 @@ module github.com/BlazesRus/timeless-jewels
 go 1.22.5
 
 require (
+	atomicgo.dev/keyboard v0.2.8
 	github.com/MarvinJWendt/testza v0.5.1
 	github.com/Vilsol/crystalline v0.0.7
+	github.com/lithammer/fuzzysearch v1.1.5
+	github.com/pterm/pterm v0.12.49
 )
 
 require (
 	atomicgo.dev/assert v0.0.2 // indirect
 	atomicgo.dev/cursor v0.1.1 // indirect
-	atomicgo.dev/keyboard v0.2.8 // indirect
 	github.com/containerd/console v1.0.3 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/gookit/color v1.5.2 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.0 // indirect
-	github.com/lithammer/fuzzysearch v1.1.5 // indirect
 	github.com/mattn/go-colorable v0.1.12 // indirect
 	github.com/mattn/go-isatty v0.0.14 // indirect
 	github.com/mattn/go-runewidth v0.0.13 // indirect
-	github.com/pterm/pterm v0.12.49 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/rs/zerolog v1.27.0 // indirect
 	github.com/sergi/go-diff v1.2.0 // indirect
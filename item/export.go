@@ -0,0 +1,85 @@
+package item
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+const separator = "--------"
+
+// Validate checks that the conqueror belongs to the jewel and the seed is a valid roll
+func Validate(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) error {
+	seedRange, ok := data.TimelessJewelSeedRanges[jewelType]
+	if !ok {
+		return fmt.Errorf("unknown jewel type: %d", jewelType)
+	}
+
+	if _, ok := data.TimelessJewelConquerors[jewelType][conqueror]; !ok {
+		return fmt.Errorf("conqueror %s does not belong to %s", conqueror, jewelType)
+	}
+
+	if seed < seedRange.Min || seed > seedRange.Max {
+		return fmt.Errorf("seed %d outside of %s range %d-%d", seed, jewelType, seedRange.Min, seedRange.Max)
+	}
+
+	if seedRange.Special && seed%20 != 0 {
+		return fmt.Errorf("%s seeds must be a multiple of 20, got %d", jewelType, seed)
+	}
+
+	return nil
+}
+
+func modLines(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) []string {
+	return []string{
+		ModLine(jewelType, conqueror, seed),
+		"Passives in radius are Conquered by the " + Factions[jewelType],
+		"Historic",
+	}
+}
+
+// PoBText renders a jewel as item text that can be pasted into Path of Building
+func PoBText(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) (string, error) {
+	if err := Validate(jewelType, conqueror, seed); err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		"Rarity: UNIQUE",
+		jewelType.String(),
+		"Timeless Jewel",
+		"League: Legion",
+		"Limited to: 1 Historic",
+		"Radius: Large",
+		"Implicits: 0",
+	}
+
+	return strings.Join(append(lines, modLines(jewelType, conqueror, seed)...), "\n"), nil
+}
+
+// GameText renders a jewel the way the game copies it to the clipboard
+func GameText(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) (string, error) {
+	if err := Validate(jewelType, conqueror, seed); err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		"Item Class: Jewels",
+		"Rarity: Unique",
+		jewelType.String(),
+		"Timeless Jewel",
+		separator,
+		"Limited to: 1 Historic",
+		"Radius: Large",
+		separator,
+	}
+
+	lines = append(lines, modLines(jewelType, conqueror, seed)...)
+	lines = append(lines,
+		separator,
+		"Place into an allocated Jewel Socket on the Passive Skill Tree. Right click to remove from the Socket.",
+	)
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,104 @@
+package item
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+const (
+	seedPlaceholder      = "{seed}"
+	conquerorPlaceholder = "{conqueror}"
+)
+
+// ModTemplates holds the in-game wording of each timeless jewel's seed and conqueror mod
+var ModTemplates = map[data.JewelType]string{
+	data.GloriousVanity:  "Bathed in the blood of {seed} sacrificed in the name of {conqueror}",
+	data.LethalPride:     "Commanded leadership over {seed} warriors under {conqueror}",
+	data.BrutalRestraint: "Denoted service of {seed} dekhara in the akhara of {conqueror}",
+	data.MilitantFaith:   "Carved to glorify {seed} new faithful converted by High Templar {conqueror}",
+	data.ElegantHubris:   "Commissioned {seed} coins to commemorate {conqueror}",
+}
+
+// Factions are the conquering factions named by the "Passives in radius are Conquered by the ..." line
+var Factions = map[data.JewelType]string{
+	data.GloriousVanity:  "Vaal",
+	data.LethalPride:     "Karui",
+	data.BrutalRestraint: "Maraketh",
+	data.MilitantFaith:   "Templars",
+	data.ElegantHubris:   "Eternal Empire",
+}
+
+type Mod struct {
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Seed      uint32
+}
+
+var modPatterns = make(map[data.JewelType]*regexp.Regexp)
+
+func init() {
+	for jewelType, template := range ModTemplates {
+		pattern := regexp.QuoteMeta(template)
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(seedPlaceholder), `(\d+)`, 1)
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(conquerorPlaceholder), `(\w+)`, 1)
+		modPatterns[jewelType] = regexp.MustCompile(`(?i)^` + pattern + `$`)
+	}
+}
+
+// ModLine renders the seed and conqueror mod of a jewel
+func ModLine(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) string {
+	line := strings.Replace(ModTemplates[jewelType], seedPlaceholder, strconv.FormatUint(uint64(seed), 10), 1)
+	return strings.Replace(line, conquerorPlaceholder, string(conqueror), 1)
+}
+
+// ParseModLine recognises the seed and conqueror mod of any timeless jewel.
+// The conqueror must belong to the jewel, the seed is not range checked.
+func ParseModLine(line string) (Mod, bool) {
+	jewelType, seed, name, ok := matchModLine(line)
+	if !ok {
+		return Mod{}, false
+	}
+
+	conqueror, ok := findConqueror(jewelType, name)
+	if !ok {
+		return Mod{}, false
+	}
+
+	return Mod{
+		JewelType: jewelType,
+		Conqueror: conqueror,
+		Seed:      seed,
+	}, true
+}
+
+// matchModLine finds the jewel type, seed and unchecked conqueror name of a mod line
+func matchModLine(line string) (data.JewelType, uint32, string, bool) {
+	line = strings.TrimSpace(line)
+	for jewelType, pattern := range modPatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		seed, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return 0, 0, "", false
+		}
+
+		return jewelType, uint32(seed), match[2], true
+	}
+
+	return 0, 0, "", false
+}
+
+func findConqueror(jewelType data.JewelType, name string) (data.Conqueror, bool) {
+	for conqueror := range data.TimelessJewelConquerors[jewelType] {
+		if strings.EqualFold(string(conqueror), name) {
+			return conqueror, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,111 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// Jewel is a timeless jewel read from copied item text
+type Jewel struct {
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Seed      uint32
+	Keystone  *data.AlternatePassiveSkill
+}
+
+var (
+	ErrNotTimelessJewel = errors.New("item is not a timeless jewel")
+
+	// advancedModHeader matches the "{ Unique Modifier }" headers of Ctrl+Alt+C copies
+	advancedModHeader = regexp.MustCompile(`^\{.*\}$`)
+	modSuffix         = regexp.MustCompile(`\s*\((implicit|crafted|enchant|fractured)\)$`)
+)
+
+// Keystone returns the keystone a conqueror grants on the jewel
+func Keystone(jewelType data.JewelType, conqueror data.Conqueror) *data.AlternatePassiveSkill {
+	timelessJewelConqueror, ok := data.TimelessJewelConquerors[jewelType][conqueror]
+	if !ok {
+		return nil
+	}
+
+	return data.GetAlternatePassiveSkillKeyStone(data.TimelessJewel{
+		AlternateTreeVersion:   data.GetAlternateTreeVersionIndex(uint32(jewelType)),
+		TimelessJewelConqueror: timelessJewelConqueror,
+	})
+}
+
+func keystoneConqueror(jewelType data.JewelType, name string) (data.Conqueror, bool) {
+	for conqueror := range data.TimelessJewelConquerors[jewelType] {
+		if keystone := Keystone(jewelType, conqueror); keystone != nil && strings.EqualFold(keystone.Name, name) {
+			return conqueror, true
+		}
+	}
+	return "", false
+}
+
+// Parse reads a timeless jewel from text copied in game or from the trade site.
+// The conqueror is taken from the mod line, or from the keystone line when the mod wording names a keystone instead.
+func Parse(text string) (*Jewel, error) {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == separator || advancedModHeader.MatchString(line) {
+			continue
+		}
+		lines = append(lines, modSuffix.ReplaceAllString(line, ""))
+	}
+
+	var (
+		jewel    *Jewel
+		modIndex int
+	)
+
+	for i, line := range lines {
+		jewelType, seed, name, ok := matchModLine(line)
+		if !ok {
+			continue
+		}
+
+		jewel = &Jewel{JewelType: jewelType, Seed: seed}
+		if conqueror, ok := findConqueror(jewelType, name); ok {
+			jewel.Conqueror = conqueror
+		}
+
+		modIndex = i
+		break
+	}
+
+	if jewel == nil {
+		return nil, ErrNotTimelessJewel
+	}
+
+	for _, line := range lines[modIndex+1:] {
+		conqueror, ok := keystoneConqueror(jewel.JewelType, line)
+		if !ok {
+			continue
+		}
+
+		if jewel.Conqueror != "" && jewel.Conqueror != conqueror {
+			return nil, fmt.Errorf("keystone %s does not belong to %s", line, jewel.Conqueror)
+		}
+
+		jewel.Conqueror = conqueror
+		break
+	}
+
+	if jewel.Conqueror == "" {
+		return nil, fmt.Errorf("could not determine the conqueror of %s", jewel.JewelType)
+	}
+
+	if err := Validate(jewel.JewelType, jewel.Conqueror, jewel.Seed); err != nil {
+		return nil, err
+	}
+
+	jewel.Keystone = Keystone(jewel.JewelType, jewel.Conqueror)
+
+	return jewel, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+	"github.com/BlazesRus/timeless-jewels/pob"
+)
+
+func TestPoBText(t *testing.T) {
+	text, err := item.PoBText(data.GloriousVanity, data.Xibaqua, 2000)
+	testza.AssertNoError(t, err)
+	testza.AssertTrue(t, strings.HasPrefix(text, "Rarity: UNIQUE\nGlorious Vanity\nTimeless Jewel\n"))
+	testza.AssertContains(t, text, "Bathed in the blood of 2000 sacrificed in the name of Xibaqua")
+
+	mod, ok := pob.ParseItemText(text)
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, item.Mod{JewelType: data.GloriousVanity, Conqueror: data.Xibaqua, Seed: 2000}, mod)
+
+	game, err := item.GameText(data.MilitantFaith, data.Avarius, 5000)
+	testza.AssertNoError(t, err)
+	testza.AssertContains(t, game, "Carved to glorify 5000 new faithful converted by High Templar Avarius\nPassives in radius are Conquered by the Templars")
+
+	_, err = item.PoBText(data.ElegantHubris, data.Cadiro, 2010)
+	testza.AssertNotNil(t, err)
+
+	_, err = item.PoBText(data.LethalPride, data.Xibaqua, 12000)
+	testza.AssertNotNil(t, err)
+}
+
+func TestParseItemText(t *testing.T) {
+	game, err := item.GameText(data.LethalPride, data.Akoya, 12000)
+	testza.AssertNoError(t, err)
+
+	jewel, err := item.Parse(game)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.LethalPride, jewel.JewelType)
+	testza.AssertEqual(t, data.Akoya, jewel.Conqueror)
+	testza.AssertEqual(t, uint32(12000), jewel.Seed)
+	testza.AssertEqual(t, "Chainbreaker", jewel.Keystone.Name)
+
+	advanced := strings.Join([]string{
+		"Item Class: Jewels",
+		"Rarity: Unique",
+		"Elegant Hubris",
+		"Timeless Jewel",
+		"--------",
+		"{ Unique Modifier }",
+		"Commissioned 57820 coins to commemorate Caspiro",
+		"Passives in radius are Conquered by the Eternal Empire",
+		"Supreme Ostentation",
+		"Historic",
+	}, "\r\n")
+
+	jewel, err = item.Parse(advanced)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.Caspiro, jewel.Conqueror)
+	testza.AssertEqual(t, uint32(57820), jewel.Seed)
+
+	_, err = item.Parse(strings.Replace(advanced, "Supreme Ostentation", "Supreme Ego", 1))
+	testza.AssertNotNil(t, err)
+
+	_, err = item.Parse("Commissioned 57821 coins to commemorate Caspiro")
+	testza.AssertNotNil(t, err)
+
+	_, err = item.Parse("Rarity: Rare\nGale Eye\nCobalt Jewel")
+	testza.AssertErrorIs(t, err, item.ErrNotTimelessJewel)
+}
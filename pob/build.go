@@ -0,0 +1,293 @@
+package pob
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+)
+
+type Build struct {
+	ClassName      string
+	AscendancyName string
+	Level          int
+	ActiveSpec     int
+	Specs          []Spec
+}
+
+type Spec struct {
+	Title          string
+	TreeVersion    string
+	ClassID        int
+	AscendancyID   int
+	Nodes          []uint32
+	MasteryEffects map[uint32]uint32
+	URL            string
+	Jewels         []SocketedJewel
+}
+
+// SocketedJewel is a timeless jewel placed in a passive tree socket
+type SocketedJewel struct {
+	SocketGraphID uint32
+	ItemID        int
+	JewelType     data.JewelType
+	Conqueror     data.Conqueror
+	Seed          uint32
+}
+
+type xmlBuild struct {
+	XMLName xml.Name `xml:"PathOfBuilding"`
+	Build   struct {
+		ClassName       string `xml:"className,attr"`
+		AscendClassName string `xml:"ascendClassName,attr"`
+		Level           int    `xml:"level,attr"`
+	} `xml:"Build"`
+	Tree struct {
+		ActiveSpec int `xml:"activeSpec,attr"`
+		Specs      []struct {
+			Title          string `xml:"title,attr"`
+			TreeVersion    string `xml:"treeVersion,attr"`
+			ClassID        int    `xml:"classId,attr"`
+			AscendClassID  int    `xml:"ascendClassId,attr"`
+			Nodes          string `xml:"nodes,attr"`
+			MasteryEffects string `xml:"masteryEffects,attr"`
+			URL            string `xml:"URL"`
+			Sockets        []struct {
+				NodeID uint32 `xml:"nodeId,attr"`
+				ItemID int    `xml:"itemId,attr"`
+			} `xml:"Sockets>Socket"`
+		} `xml:"Spec"`
+	} `xml:"Tree"`
+	Items []struct {
+		ID   int    `xml:"id,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"Items>Item"`
+}
+
+// DecodeBuildCode turns a PoB export code (URL safe base64 of zlib compressed XML) into XML
+func DecodeBuildCode(code string) ([]byte, error) {
+	code = strings.Join(strings.Fields(code), "")
+	code = strings.NewReplacer("-", "+", "_", "/").Replace(code)
+	code = strings.TrimRight(code, "=")
+
+	compressed, err := base64.RawStdEncoding.DecodeString(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode build code: %w", err)
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open build code: %w", err)
+	}
+	defer reader.Close()
+
+	all, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inflate build code: %w", err)
+	}
+
+	return all, nil
+}
+
+// ParseBuildCode decodes and parses a PoB export code
+func ParseBuildCode(code string) (*Build, error) {
+	raw, err := DecodeBuildCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBuildXML(raw)
+}
+
+// ReadBuildFile parses a PoB .xml build file, or a text file holding an export code
+func ReadBuildFile(path string) (*Build, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build: %w", err)
+	}
+
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] != '<' {
+		return ParseBuildCode(string(trimmed))
+	}
+
+	return ParseBuildXML(raw)
+}
+
+func ParseBuildXML(raw []byte) (*Build, error) {
+	var parsed xmlBuild
+	if err := xml.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse build xml: %w", err)
+	}
+
+	items := make(map[int]string, len(parsed.Items))
+	for _, i := range parsed.Items {
+		items[i.ID] = i.Text
+	}
+
+	build := &Build{
+		ClassName:      parsed.Build.ClassName,
+		AscendancyName: parsed.Build.AscendClassName,
+		Level:          parsed.Build.Level,
+		ActiveSpec:     max(parsed.Tree.ActiveSpec-1, 0),
+		Specs:          make([]Spec, 0, len(parsed.Tree.Specs)),
+	}
+
+	for _, s := range parsed.Tree.Specs {
+		spec := Spec{
+			Title:          s.Title,
+			TreeVersion:    s.TreeVersion,
+			ClassID:        s.ClassID,
+			AscendancyID:   s.AscendClassID,
+			URL:            strings.TrimSpace(s.URL),
+			MasteryEffects: make(map[uint32]uint32),
+		}
+
+		nodes, err := parseIDList(s.Nodes)
+		if err != nil {
+			return nil, err
+		}
+		spec.Nodes = nodes
+
+		for _, pair := range masteryEffectPattern.FindAllStringSubmatch(s.MasteryEffects, -1) {
+			node, _ := strconv.ParseUint(pair[1], 10, 32)
+			effect, _ := strconv.ParseUint(pair[2], 10, 32)
+			spec.MasteryEffects[uint32(node)] = uint32(effect)
+		}
+
+		for _, socket := range s.Sockets {
+			if socket.ItemID == 0 {
+				continue
+			}
+
+			mod, ok := ParseItemText(items[socket.ItemID])
+			if !ok {
+				continue
+			}
+
+			spec.Jewels = append(spec.Jewels, SocketedJewel{
+				SocketGraphID: socket.NodeID,
+				ItemID:        socket.ItemID,
+				JewelType:     mod.JewelType,
+				Conqueror:     mod.Conqueror,
+				Seed:          mod.Seed,
+			})
+		}
+
+		build.Specs = append(build.Specs, spec)
+	}
+
+	if len(build.Specs) > 0 && build.ActiveSpec >= len(build.Specs) {
+		build.ActiveSpec = len(build.Specs) - 1
+	}
+
+	return build, nil
+}
+
+// Active returns the spec the build was saved with
+func (b *Build) Active() *Spec {
+	if len(b.Specs) == 0 {
+		return nil
+	}
+	return &b.Specs[b.ActiveSpec]
+}
+
+// PassiveIDs maps the allocated tree nodes to passive skill indices usable by Calculate and ReverseSearch
+func (s *Spec) PassiveIDs() []uint32 {
+	ids := make([]uint32, 0, len(s.Nodes))
+	for _, node := range s.Nodes {
+		if skill := data.GetPassiveSkillByGraphID(node); skill != nil {
+			ids = append(ids, skill.Index)
+		}
+	}
+	return ids
+}
+
+var (
+	masteryEffectPattern = regexp.MustCompile(`\{(\d+),(\d+)\}`)
+	variantPattern       = regexp.MustCompile(`\{variant:([\d,]+)\}`)
+	rangePattern         = regexp.MustCompile(`\{range:([\d.]+)\}`)
+	tagPattern           = regexp.MustCompile(`\{[^}]*\}`)
+	rollPattern          = regexp.MustCompile(`\((\d+)-(\d+)\)`)
+)
+
+// ParseItemText finds the timeless jewel mod in a PoB item, honouring variants and range rolls
+func ParseItemText(text string) (item.Mod, bool) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	selectedVariant := ""
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "Selected Variant:"); ok {
+			selectedVariant = strings.TrimSpace(value)
+		}
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		if variants := variantPattern.FindStringSubmatch(line); variants != nil && selectedVariant != "" {
+			if !containsVariant(variants[1], selectedVariant) {
+				continue
+			}
+		}
+
+		roll := 0.5
+		if match := rangePattern.FindStringSubmatch(line); match != nil {
+			roll, _ = strconv.ParseFloat(match[1], 64)
+		}
+
+		line = tagPattern.ReplaceAllString(line, "")
+		rolled := rollPattern.MatchString(line)
+		line = rollPattern.ReplaceAllStringFunc(line, func(bounds string) string {
+			match := rollPattern.FindStringSubmatch(bounds)
+			low, _ := strconv.ParseFloat(match[1], 64)
+			high, _ := strconv.ParseFloat(match[2], 64)
+			return strconv.FormatFloat(math.Round(low+(high-low)*roll), 'f', 0, 64)
+		})
+
+		if mod, ok := item.ParseModLine(line); ok {
+			if rolled && data.TimelessJewelSeedRanges[mod.JewelType].Special {
+				mod.Seed = (mod.Seed + 10) / 20 * 20
+			}
+			return mod, true
+		}
+	}
+
+	return item.Mod{}, false
+}
+
+func containsVariant(list string, variant string) bool {
+	for _, v := range strings.Split(list, ",") {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+func parseIDList(list string) ([]uint32, error) {
+	ids := make([]uint32, 0)
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		id, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node id %q: %w", field, err)
+		}
+
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/pob"
+)
+
+const testBuildXML = `<?xml version="1.0" encoding="UTF-8"?>
+<PathOfBuilding>
+	<Build level="92" className="Templar" ascendClassName="Inquisitor"/>
+	<Tree activeSpec="2">
+		<Spec title="Leveling" treeVersion="3_24" classId="5" ascendClassId="1" nodes="61525,63965"/>
+		<Spec title="Endgame" treeVersion="3_25" classId="5" ascendClassId="1" nodes="61525,63965,26725" masteryEffects="{53188,64875},{8001,12}">
+			<URL>https://www.pathofexile.com/passive-skill-tree/AAAABgUB</URL>
+			<Sockets>
+				<Socket nodeId="26725" itemId="3"/>
+				<Socket nodeId="36634" itemId="0"/>
+			</Sockets>
+		</Spec>
+	</Tree>
+	<Items activeItemSet="1">
+		<Item id="3">
+Rarity: UNIQUE
+Glorious Vanity
+Timeless Jewel
+Selected Variant: 2
+Radius: Large
+Implicits: 0
+{variant:1}Bathed in the blood of (100-8000) sacrificed in the name of Doryani
+{variant:2}Bathed in the blood of 2000 sacrificed in the name of Xibaqua
+Passives in radius are Conquered by the Vaal
+Historic
+		</Item>
+	</Items>
+</PathOfBuilding>`
+
+func TestParseBuildCode(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	_, err := writer.Write([]byte(testBuildXML))
+	testza.AssertNoError(t, err)
+	testza.AssertNoError(t, writer.Close())
+
+	code := base64.URLEncoding.EncodeToString(compressed.Bytes())
+
+	build, err := pob.ParseBuildCode(code)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "Inquisitor", build.AscendancyName)
+	testza.AssertLen(t, build.Specs, 2)
+
+	spec := build.Active()
+	testza.AssertEqual(t, "3_25", spec.TreeVersion)
+	testza.AssertEqual(t, []uint32{61525, 63965, 26725}, spec.Nodes)
+	testza.AssertEqual(t, uint32(64875), spec.MasteryEffects[53188])
+	testza.AssertEqual(t, []pob.SocketedJewel{{
+		SocketGraphID: 26725,
+		ItemID:        3,
+		JewelType:     data.GloriousVanity,
+		Conqueror:     data.Xibaqua,
+		Seed:          2000,
+	}}, spec.Jewels)
+
+	for _, id := range spec.PassiveIDs() {
+		testza.AssertNotNil(t, data.GetPassiveSkillByIndex(id))
+	}
+}
+
+func TestParsePoBItemRange(t *testing.T) {
+	mod, ok := pob.ParseItemText("Elegant Hubris\nTimeless Jewel\n{range:0.5}Commissioned (2000-160000) coins to commemorate Cadiro")
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, data.ElegantHubris, mod.JewelType)
+	testza.AssertEqual(t, uint32(81000), mod.Seed)
+}
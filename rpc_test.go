@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/api"
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+type rpcReply struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *api.RPCError   `json:"error"`
+}
+
+func TestRPCServer(t *testing.T) {
+	inReader, inWriter := io.Pipe()
+	outReader, outWriter := io.Pipe()
+
+	done := make(chan error)
+	go func() {
+		done <- api.NewRPCServer(outWriter).Serve(context.Background(), inReader)
+		outWriter.Close()
+	}()
+
+	replies := make(chan rpcReply)
+	go func() {
+		scanner := bufio.NewScanner(outReader)
+		scanner.Buffer(make([]byte, 0, 1<<16), 1<<26)
+		for scanner.Scan() {
+			var reply rpcReply
+			if err := json.Unmarshal(scanner.Bytes(), &reply); err == nil {
+				replies <- reply
+			}
+		}
+		close(replies)
+	}()
+
+	send := func(message string) {
+		_, err := io.WriteString(inWriter, message+"\n")
+		testza.AssertNoError(t, err)
+	}
+
+	// Positional params mirror the WASM function arguments
+	send(`{"jsonrpc":"2.0","id":1,"method":"Calculate","params":[1210,1001,1,"Xibaqua"]}`)
+	reply := <-replies
+	testza.AssertEqual(t, 1, *reply.ID)
+
+	var result data.AlternatePassiveSkillInformation
+	testza.AssertNoError(t, json.Unmarshal(reply.Result, &result))
+	testza.AssertEqual(t, calculator.Calculate(1210, 1001, data.GloriousVanity, data.Xibaqua), result)
+
+	send(`{"jsonrpc":"2.0","id":2,"method":"Unknown"}`)
+	reply = <-replies
+	testza.AssertEqual(t, api.MethodNotFound, reply.Error.Code)
+
+	send(`{"jsonrpc":"2.0","id":4,"method":"Constants"}`)
+	reply = <-replies
+	testza.AssertEqual(t, 4, *reply.ID)
+
+	var constants api.Constants
+	testza.AssertNoError(t, json.Unmarshal(reply.Result, &constants))
+	testza.AssertEqual(t, data.TimelessJewelSeedRanges, constants.TimelessJewelSeedRanges)
+	testza.AssertEqual(t, "Glorious Vanity", constants.TimelessJewels[data.GloriousVanity])
+	testza.AssertEqual(t, data.TimelessJewelConquerors, constants.TimelessJewelConquerors)
+
+	send(`{"jsonrpc":"2.0","id":3,"method":"Search","params":{"Socket":26725,"JewelType":1,"Conqueror":"Ahuana","Options":{"Stats":[{"ID":25,"Weight":1}]}}}`)
+	reply = <-replies
+	testza.AssertEqual(t, api.ProgressNotification, reply.Method)
+
+	send(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":3}}`)
+	for reply = range replies {
+		if reply.ID != nil {
+			break
+		}
+	}
+	testza.AssertEqual(t, 3, *reply.ID)
+	testza.AssertEqual(t, api.RequestCancelled, reply.Error.Code)
+
+	go func() {
+		for range replies {
+		}
+	}()
+
+	inWriter.Close()
+	testza.AssertNoError(t, <-done)
+}
+
+func TestRPCServerPanic(t *testing.T) {
+	api.Methods["Panic"] = api.Method{
+		Call: func(context.Context, json.RawMessage, calculator.JewelUpdateFunc) (any, error) {
+			panic("broken method")
+		},
+	}
+	defer delete(api.Methods, "Panic")
+
+	outReader, outWriter := io.Pipe()
+	go func() {
+		_ = api.NewRPCServer(outWriter).Serve(context.Background(), strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"Panic"}`+"\n"))
+		outWriter.Close()
+	}()
+
+	var reply rpcReply
+	testza.AssertNoError(t, json.NewDecoder(outReader).Decode(&reply))
+	testza.AssertEqual(t, 1, *reply.ID)
+	testza.AssertEqual(t, api.InternalError, reply.Error.Code)
+	go io.Copy(io.Discard, outReader)
+}
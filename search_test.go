@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+const testSocket = 26725
+
+func TestCalculateSocket(t *testing.T) {
+	passives := tree.PassivesInRadius(testSocket)
+	testza.AssertGreater(t, len(passives), 0)
+
+	results := calculator.CalculateSocket(testSocket, 2000, data.GloriousVanity, data.Xibaqua)
+	testza.AssertLen(t, results, len(passives))
+
+	for _, skill := range passives {
+		testza.AssertEqual(t, calculator.Calculate(skill.Index, 2000, data.GloriousVanity, data.Xibaqua), results[skill.Index])
+	}
+}
+
+func TestSearchAllocation(t *testing.T) {
+	stats := []calculator.StatWeight{{ID: 25, Weight: 1}}
+
+	everything := calculator.Search(passiveIDs, data.GloriousVanity, data.Xibaqua, calculator.SearchOptions{Stats: stats}, nil)
+	testza.AssertEqual(t, 7263, len(everything))
+
+	allocation := calculator.NewAllocation([]uint32{1210})
+	allocation.PathCosts[2205] = 3
+
+	options := calculator.SearchOptions{
+		Stats:          stats,
+		MinTotalWeight: 1,
+		Allocation:     allocation,
+	}
+
+	allocatedOnly := calculator.Search(passiveIDs, data.GloriousVanity, data.Xibaqua, options, nil)
+	testza.AssertGreater(t, len(allocatedOnly), 0)
+	for _, score := range allocatedOnly {
+		testza.AssertEqual(t, float64(1), score.Weight)
+		testza.AssertEqual(t, float64(0), score.Potential)
+	}
+
+	options.UnallocatedWeight = 1
+	options.MinTotalWeight = 0.25
+	withPotential := calculator.Search(passiveIDs, data.GloriousVanity, data.Xibaqua, options, nil)
+	testza.AssertGreater(t, len(withPotential), len(allocatedOnly))
+
+	for _, score := range withPotential {
+		if score.Seed != 1001 {
+			continue
+		}
+
+		// 2340 also rolls the stat but has no path cost, so it is unreachable and left out
+		testza.AssertEqual(t, float64(1), score.Weight)
+		testza.AssertEqual(t, 0.25, score.Potential)
+		testza.AssertEqual(t, uint32(3), score.PotentialCost)
+		testza.AssertEqual(t, 2, score.StatCounts[25])
+		for _, node := range score.Nodes {
+			testza.AssertNotEqual(t, uint32(2340), node.Passive)
+		}
+	}
+
+	socket := calculator.ScoreSocket(testSocket, 1001, data.GloriousVanity, data.Xibaqua, calculator.SearchOptions{Stats: stats})
+	testza.AssertEqual(t, uint32(1001), socket.Seed)
+	for _, node := range socket.Nodes {
+		testza.AssertTrue(t, node.Allocated)
+	}
+}
+
+func TestSearchValuePerPoint(t *testing.T) {
+	allocation, err := calculator.SocketAllocation(&tree.Allocation{ClassID: 1}, testSocket)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, uint32(9), allocation.SocketCost)
+	testza.AssertGreater(t, len(allocation.PathCosts), 0)
+
+	options := calculator.SearchOptions{
+		Stats:             []calculator.StatWeight{{ID: 25, Weight: 1}},
+		Allocation:        allocation,
+		UnallocatedWeight: 1,
+	}
+
+	scores := calculator.Search(calculator.SocketPassiveIDs(testSocket), data.GloriousVanity, data.Xibaqua, options, nil)
+	testza.AssertGreater(t, len(scores), 0)
+
+	calculator.RankByValuePerPoint(scores)
+	for i, score := range scores {
+		testza.AssertEqual(t, allocation.SocketCost+score.PotentialCost, score.PointCost)
+		if i > 0 {
+			testza.AssertTrue(t, scores[i-1].ValuePerPoint() >= score.ValuePerPoint())
+		}
+	}
+}
+
+func TestPlanAllocation(t *testing.T) {
+	stats := []calculator.StatWeight{{ID: 25, Weight: 1}}
+
+	full, err := calculator.PlanAllocation(nil, 1, testSocket, 1001, data.GloriousVanity, data.Xibaqua, stats, 0)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, uint32(len(full.Nodes)), full.Points)
+	testza.AssertEqual(t, uint32(testSocket), full.Nodes[8])
+
+	socket := calculator.ScoreSocket(testSocket, 1001, data.GloriousVanity, data.Xibaqua, calculator.SearchOptions{Stats: stats})
+	testza.AssertEqual(t, socket.Weight, full.Weight)
+	testza.AssertEqual(t, len(socket.Nodes), len(full.Captured))
+
+	limited, err := calculator.PlanAllocation(nil, 1, testSocket, 1001, data.GloriousVanity, data.Xibaqua, stats, 12)
+	testza.AssertNoError(t, err)
+	testza.AssertTrue(t, limited.Points <= 12)
+	testza.AssertTrue(t, limited.Weight <= full.Weight)
+
+	_, err = calculator.PlanAllocation(nil, 1, testSocket, 1001, data.GloriousVanity, data.Xibaqua, stats, 5)
+	testza.AssertNotNil(t, err)
+}
+
+func TestSearchPassiveTypes(t *testing.T) {
+	notables := calculator.FilterPassiveTypes(passiveIDs, []data.PassiveSkillType{data.Notable})
+	testza.AssertGreater(t, len(notables), 0)
+	testza.AssertGreater(t, len(passiveIDs), len(notables))
+	for _, id := range notables {
+		testza.AssertTrue(t, data.GetPassiveSkillByIndex(id).IsNotable)
+	}
+
+	options := calculator.SearchOptions{
+		Stats:        []calculator.StatWeight{{ID: 25, Weight: 1}},
+		PassiveTypes: []data.PassiveSkillType{data.Notable},
+	}
+
+	socket := calculator.ScoreSocket(testSocket, 1001, data.GloriousVanity, data.Xibaqua, options)
+	for _, node := range socket.Nodes {
+		testza.AssertTrue(t, data.GetPassiveSkillByIndex(node.Passive).IsNotable)
+	}
+
+	for _, score := range calculator.Search(passiveIDs, data.GloriousVanity, data.Xibaqua, options, nil) {
+		for _, node := range score.Nodes {
+			testza.AssertTrue(t, data.GetPassiveSkillByIndex(node.Passive).IsNotable)
+		}
+	}
+}
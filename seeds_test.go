@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/calculator"
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func TestCompareSeeds(t *testing.T) {
+	options := calculator.SearchOptions{Stats: []calculator.StatWeight{{ID: 25, Weight: 1}}}
+	jewels := []calculator.Jewel{
+		{JewelType: data.GloriousVanity, Conqueror: data.Xibaqua, Seed: 1001},
+		{JewelType: data.GloriousVanity, Conqueror: data.Xibaqua, Seed: 2000},
+		{JewelType: data.LethalPride, Conqueror: data.Kaom, Seed: 10000},
+	}
+
+	comparison, err := calculator.CompareSeeds(testSocket, jewels, options, data.English)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, comparison.Scores, 3)
+	testza.AssertEqual(t, float64(0), comparison.Deltas[0])
+	testza.AssertEqual(t, comparison.Scores[1].Total()-comparison.Scores[0].Total(), comparison.Deltas[1])
+	testza.AssertLen(t, comparison.Nodes, len(calculator.CompareSocket(testSocket, 1001, data.GloriousVanity, data.Xibaqua, data.English)))
+
+	for _, node := range comparison.Nodes {
+		testza.AssertLen(t, node.Jewels, 3)
+		testza.AssertTrue(t, node.Differs)
+	}
+
+	same, err := calculator.CompareSeeds(testSocket, []calculator.Jewel{jewels[0], jewels[0]}, options, data.English)
+	testza.AssertNoError(t, err)
+	for _, node := range same.Nodes {
+		testza.AssertFalse(t, node.Differs)
+	}
+
+	_, err = calculator.CompareSeeds(testSocket, jewels[:1], options, data.English)
+	testza.AssertNotNil(t, err)
+
+	_, err = calculator.CompareSeeds(testSocket, []calculator.Jewel{jewels[0], {JewelType: data.LethalPride, Conqueror: data.Xibaqua, Seed: 10000}}, options, data.English)
+	testza.AssertNotNil(t, err)
+}
+
+func TestSearchAllJewels(t *testing.T) {
+	options := calculator.SearchOptions{
+		Stats:          []calculator.StatWeight{{ID: 25, Weight: 1}},
+		MinTotalWeight: 1,
+	}
+
+	scores := calculator.SearchAllJewels(passiveIDs[:3], options, nil)
+	testza.AssertGreater(t, len(scores), 0)
+	testza.AssertEqual(t, float64(1), scores[0].Normalized)
+
+	jewelTypes := make(map[data.JewelType]bool)
+	for i, score := range scores {
+		jewelTypes[score.JewelType] = true
+		testza.AssertTrue(t, score.Normalized > 0 && score.Normalized <= 1)
+		if i > 0 {
+			testza.AssertTrue(t, scores[i-1].Normalized >= score.Normalized)
+		}
+	}
+	testza.AssertGreater(t, len(jewelTypes), 1)
+}
+
+func TestRankJewelScores(t *testing.T) {
+	scores := []calculator.JewelScore{
+		{SeedScore: calculator.SeedScore{Seed: 4, Weight: 9}, JewelType: data.LethalPride, Conqueror: data.Kaom},
+		{SeedScore: calculator.SeedScore{Seed: 2, Weight: 30}, JewelType: data.GloriousVanity, Conqueror: data.Xibaqua},
+		{SeedScore: calculator.SeedScore{Seed: 3, Weight: 30}, JewelType: data.GloriousVanity, Conqueror: data.Doryani},
+		{SeedScore: calculator.SeedScore{Seed: 1, Weight: 40}, JewelType: data.GloriousVanity, Conqueror: data.Xibaqua},
+		{SeedScore: calculator.SeedScore{Seed: 5, Weight: 30}, JewelType: data.BrutalRestraint, Conqueror: data.Asenath},
+	}
+
+	calculator.RankJewelScores(scores)
+
+	// The best total wins whichever jewel it comes from, ties go by jewel type, conqueror and seed
+	seeds := make([]uint32, len(scores))
+	for i, score := range scores {
+		seeds[i] = score.Seed
+	}
+	testza.AssertEqual(t, []uint32{1, 3, 2, 5, 4}, seeds)
+	testza.AssertEqual(t, float64(1), scores[0].Normalized)
+	testza.AssertEqual(t, 0.75, scores[1].Normalized)
+	testza.AssertEqual(t, 0.225, scores[4].Normalized)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func TestSearchStats(t *testing.T) {
+	devotion := data.SearchStats("devotion", data.MilitantFaith, data.English)
+	testza.AssertGreater(t, len(devotion), 0)
+	testza.AssertEqual(t, "base_devotion", devotion[0].ID)
+
+	attackSpeed := data.SearchStats("attack speed", 0, data.English)
+	testza.AssertGreater(t, len(attackSpeed), 0)
+	testza.AssertEqual(t, uint32(70), attackSpeed[0].Index)
+
+	testza.AssertLen(t, data.SearchStats("devotion", data.GloriousVanity, data.English), 0)
+	testza.AssertLen(t, data.SearchStats("  ", 0, data.English), 0)
+}
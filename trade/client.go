@@ -0,0 +1,328 @@
+package trade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/item"
+)
+
+// FetchPageSize is the most listings the fetch endpoint returns per request
+const FetchPageSize = 10
+
+const maxRetries = 3
+
+const (
+	searchEndpoint = "search"
+	fetchEndpoint  = "fetch"
+)
+
+type Client struct {
+	BaseURL    string
+	UserAgent  string
+	HTTPClient *http.Client
+	Limiter    *RateLimiter
+}
+
+// NewClient creates a trade API client, an empty base URL points it at pathofexile.com
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		UserAgent:  "timeless-jewels",
+		HTTPClient: http.DefaultClient,
+		Limiter:    NewRateLimiter(),
+	}
+}
+
+type SearchResponse struct {
+	ID     string   `json:"id"`
+	Result []string `json:"result"`
+	Total  int      `json:"total"`
+}
+
+type Price struct {
+	Type     string  `json:"type"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// ChaosValue converts the price using chaos equivalent rates, chaos itself is always worth 1
+func (p Price) ChaosValue(rates map[string]float64) (float64, bool) {
+	if p.Currency == "chaos" {
+		return p.Amount, true
+	}
+
+	rate, ok := rates[p.Currency]
+	if !ok {
+		return 0, false
+	}
+
+	return p.Amount * rate, true
+}
+
+type Listing struct {
+	ID        string
+	Account   string
+	Whisper   string
+	Price     *Price
+	Name      string
+	JewelType data.JewelType
+	Conqueror data.Conqueror
+	Seed      uint32
+}
+
+type fetchResponse struct {
+	Result []*struct {
+		ID      string `json:"id"`
+		Listing struct {
+			Account struct {
+				Name string `json:"name"`
+			} `json:"account"`
+			Whisper string `json:"whisper"`
+			Price   *Price `json:"price"`
+		} `json:"listing"`
+		Item struct {
+			Name         string   `json:"name"`
+			ExplicitMods []string `json:"explicitMods"`
+		} `json:"item"`
+	} `json:"result"`
+}
+
+// parseJewelMods finds the seed and conqueror in the explicit mods of a timeless jewel
+func parseJewelMods(mods []string) (data.JewelType, data.Conqueror, uint32, bool) {
+	for _, mod := range mods {
+		if parsed, ok := item.ParseModLine(mod); ok {
+			return parsed.JewelType, parsed.Conqueror, parsed.Seed, true
+		}
+	}
+
+	return 0, "", 0, false
+}
+
+func (c *Client) do(ctx context.Context, endpoint string, method string, target string, body []byte, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		if err := c.Limiter.Wait(ctx, endpoint); err != nil {
+			return err
+		}
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, target, reader)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("User-Agent", c.UserAgent)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("trade request failed: %w", err)
+		}
+
+		c.Limiter.Update(endpoint, resp.Header)
+
+		all, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read trade response: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.Limiter.Throttled(endpoint)
+			if attempt < maxRetries {
+				continue
+			}
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("trade api returned %s: %s", resp.Status, strings.TrimSpace(string(all)))
+		}
+
+		if err := json.Unmarshal(all, out); err != nil {
+			return fmt.Errorf("failed to decode trade response: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Search submits a query and returns the search ID with the matching listing IDs
+func (c *Client) Search(ctx context.Context, platform Platform, league string, query Query) (*SearchResponse, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	target := c.BaseURL + "/api" + SearchPath(platform, league)
+
+	var response SearchResponse
+	if err := c.do(ctx, searchEndpoint, http.MethodPost, target, body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Fetch loads the listings of a search, paging through the IDs FetchPageSize at a time
+func (c *Client) Fetch(ctx context.Context, searchID string, ids []string) ([]Listing, error) {
+	listings := make([]Listing, 0, len(ids))
+	for _, page := range chunk(ids, FetchPageSize) {
+		target := c.BaseURL + "/api/trade/fetch/" + strings.Join(page, ",") + "?query=" + url.QueryEscape(searchID)
+
+		var response fetchResponse
+		if err := c.do(ctx, fetchEndpoint, http.MethodGet, target, nil, &response); err != nil {
+			return nil, err
+		}
+
+		for _, result := range response.Result {
+			if result == nil {
+				continue
+			}
+
+			listing := Listing{
+				ID:      result.ID,
+				Account: result.Listing.Account.Name,
+				Whisper: result.Listing.Whisper,
+				Price:   result.Listing.Price,
+				Name:    result.Item.Name,
+			}
+
+			listing.JewelType, listing.Conqueror, listing.Seed, _ = parseJewelMods(result.Item.ExplicitMods)
+			listings = append(listings, listing)
+		}
+	}
+
+	return listings, nil
+}
+
+// SearchSeeds looks up listings for seeds, running every filter group of the generated queries.
+// A limit above zero caps the listings fetched per search.
+func (c *Client) SearchSeeds(ctx context.Context, jewelType data.JewelType, conqueror data.Conqueror, seeds []uint32, platform Platform, league string, limit int) ([]Listing, error) {
+	queries, err := ConstructQueries(jewelType, conqueror, seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	listings := make([]Listing, 0)
+	for _, query := range queries {
+		for _, group := range query.Query.Stats {
+			group.Disabled = false
+			query.Query.Stats = []FilterGroup{group}
+
+			response, err := c.Search(ctx, platform, league, query)
+			if err != nil {
+				return nil, err
+			}
+
+			ids := response.Result
+			if limit > 0 && len(ids) > limit {
+				ids = ids[:limit]
+			}
+
+			fetched, err := c.Fetch(ctx, response.ID, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			listings = append(listings, fetched...)
+		}
+	}
+
+	return listings, nil
+}
+
+type PricedResult struct {
+	Seed       uint32
+	JewelType  data.JewelType
+	Conqueror  data.Conqueror
+	ChaosValue float64
+	Listings   []Listing
+	Passives   map[uint32]map[uint32]uint32
+}
+
+// RankByPrice joins listings onto the ReverseSearch results of one jewel type and conqueror
+// and orders the listed seeds by their cheapest listing. Listings of other jewels are ignored.
+// Prices in currencies missing from rates are kept but sorted after every known price.
+func RankByPrice(jewelType data.JewelType, conqueror data.Conqueror, results map[uint32]map[uint32]map[uint32]uint32, listings []Listing, rates map[string]float64) []PricedResult {
+	bySeed := make(map[uint32]*PricedResult)
+	for _, listing := range listings {
+		if listing.JewelType != jewelType || listing.Conqueror != conqueror {
+			continue
+		}
+
+		passives, ok := results[listing.Seed]
+		if !ok {
+			continue
+		}
+
+		if _, ok := bySeed[listing.Seed]; !ok {
+			bySeed[listing.Seed] = &PricedResult{
+				Seed:       listing.Seed,
+				JewelType:  jewelType,
+				Conqueror:  conqueror,
+				ChaosValue: -1,
+				Passives:   passives,
+			}
+		}
+
+		bySeed[listing.Seed].Listings = append(bySeed[listing.Seed].Listings, listing)
+	}
+
+	ranked := make([]PricedResult, 0, len(bySeed))
+	for _, result := range bySeed {
+		sort.SliceStable(result.Listings, func(i, j int) bool {
+			return listingValue(result.Listings[i], rates) < listingValue(result.Listings[j], rates)
+		})
+
+		if price := result.Listings[0].Price; price != nil {
+			if value, ok := price.ChaosValue(rates); ok {
+				result.ChaosValue = value
+			}
+		}
+
+		ranked = append(ranked, *result)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := listingValue(ranked[i].Listings[0], rates), listingValue(ranked[j].Listings[0], rates)
+		if a != b {
+			return a < b
+		}
+
+		return ranked[i].Seed < ranked[j].Seed
+	})
+
+	return ranked
+}
+
+const unknownPrice = 1e18
+
+func listingValue(listing Listing, rates map[string]float64) float64 {
+	if listing.Price == nil {
+		return unknownPrice
+	}
+
+	if value, ok := listing.Price.ChaosValue(rates); ok {
+		return value
+	}
+
+	return unknownPrice
+}
@@ -0,0 +1,244 @@
+package trade
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+const DefaultBaseURL = "https://www.pathofexile.com"
+
+const (
+	// MaxFiltersPerGroup is the most stat filters the trade site accepts in a single filter group
+	MaxFiltersPerGroup = 50
+
+	// MaxGroupsPerQuery is how many filter groups are packed into a single trade link
+	MaxGroupsPerQuery = 4
+
+	MaxFiltersPerQuery = MaxFiltersPerGroup * MaxGroupsPerQuery
+)
+
+type Platform string
+
+const (
+	PC          = Platform("PC")
+	Xbox        = Platform("Xbox")
+	Playstation = Platform("Playstation")
+)
+
+// Realm returns the trade site path segment of a platform, PC has none
+func (p Platform) Realm() string {
+	switch p {
+	case Xbox:
+		return "xbox"
+	case Playstation:
+		return "sony"
+	default:
+		return ""
+	}
+}
+
+var StatNames = map[data.JewelType]map[data.Conqueror]string{
+	data.GloriousVanity: {
+		data.Ahuana:  "explicit.pseudo_timeless_jewel_ahuana",
+		data.Xibaqua: "explicit.pseudo_timeless_jewel_xibaqua",
+		data.Doryani: "explicit.pseudo_timeless_jewel_doryani",
+		data.Zerphi:  "explicit.pseudo_timeless_jewel_zerphi",
+	},
+	data.LethalPride: {
+		data.Kaom:    "explicit.pseudo_timeless_jewel_kaom",
+		data.Rakiata: "explicit.pseudo_timeless_jewel_rakiata",
+		data.Kiloava: "explicit.pseudo_timeless_jewel_kiloava",
+		data.Akoya:   "explicit.pseudo_timeless_jewel_akoya",
+	},
+	data.BrutalRestraint: {
+		data.Deshret: "explicit.pseudo_timeless_jewel_deshret",
+		data.Balbala: "explicit.pseudo_timeless_jewel_balbala",
+		data.Asenath: "explicit.pseudo_timeless_jewel_asenath",
+		data.Nasima:  "explicit.pseudo_timeless_jewel_nasima",
+	},
+	data.MilitantFaith: {
+		data.Venarius: "explicit.pseudo_timeless_jewel_venarius",
+		data.Maxarius: "explicit.pseudo_timeless_jewel_maxarius",
+		data.Dominus:  "explicit.pseudo_timeless_jewel_dominus",
+		data.Avarius:  "explicit.pseudo_timeless_jewel_avarius",
+	},
+	data.ElegantHubris: {
+		data.Cadiro:   "explicit.pseudo_timeless_jewel_cadiro",
+		data.Victario: "explicit.pseudo_timeless_jewel_victario",
+		data.Chitus:   "explicit.pseudo_timeless_jewel_chitus",
+		data.Caspiro:  "explicit.pseudo_timeless_jewel_caspiro",
+	},
+}
+
+type FilterValue struct {
+	Min uint32 `json:"min"`
+	Max uint32 `json:"max"`
+}
+
+type Filter struct {
+	ID       string      `json:"id"`
+	Value    FilterValue `json:"value"`
+	Disabled bool        `json:"disabled,omitempty"`
+}
+
+type GroupValue struct {
+	Min uint32 `json:"min"`
+}
+
+type FilterGroup struct {
+	Type     string     `json:"type"`
+	Value    GroupValue `json:"value"`
+	Filters  []Filter   `json:"filters"`
+	Disabled bool       `json:"disabled"`
+}
+
+type Status struct {
+	Option string `json:"option"`
+}
+
+type QueryBody struct {
+	Status Status        `json:"status"`
+	Stats  []FilterGroup `json:"stats"`
+}
+
+type Sort struct {
+	Price string `json:"price"`
+}
+
+type Query struct {
+	Query QueryBody `json:"query"`
+	Sort  Sort      `json:"sort"`
+}
+
+// Conquerors returns the conquerors a query for the given conqueror should cover, an empty conqueror means any
+func Conquerors(jewelType data.JewelType, conqueror data.Conqueror) []data.Conqueror {
+	if conqueror != "" {
+		return []data.Conqueror{conqueror}
+	}
+
+	conquerors := make([]data.Conqueror, 0, len(StatNames[jewelType]))
+	for conq := range StatNames[jewelType] {
+		conquerors = append(conquerors, conq)
+	}
+
+	sort.Slice(conquerors, func(i, j int) bool {
+		return conquerors[i] < conquerors[j]
+	})
+
+	return conquerors
+}
+
+func constructSearchFilters(jewelType data.JewelType, conqueror data.Conqueror, seed uint32) []Filter {
+	conquerors := Conquerors(jewelType, conqueror)
+	filters := make([]Filter, 0, len(conquerors))
+	for _, conq := range conquerors {
+		filters = append(filters, Filter{
+			ID: StatNames[jewelType][conq],
+			Value: FilterValue{
+				Min: seed,
+				Max: seed,
+			},
+		})
+	}
+
+	return filters
+}
+
+// ConstructQueries turns seeds into trade queries, an empty conqueror searches for any conqueror.
+// Each query holds up to MaxGroupsPerQuery count groups of up to MaxFiltersPerGroup filters, only the first group is enabled.
+func ConstructQueries(jewelType data.JewelType, conqueror data.Conqueror, seeds []uint32) ([]Query, error) {
+	if _, ok := StatNames[jewelType]; !ok {
+		return nil, fmt.Errorf("unknown jewel type: %d", jewelType)
+	}
+
+	if conqueror != "" {
+		if _, ok := StatNames[jewelType][conqueror]; !ok {
+			return nil, fmt.Errorf("conqueror %s does not belong to %s", conqueror, jewelType)
+		}
+	}
+
+	allFilters := make([]Filter, 0, len(seeds))
+	for _, seed := range seeds {
+		allFilters = append(allFilters, constructSearchFilters(jewelType, conqueror, seed)...)
+	}
+
+	queries := make([]Query, 0)
+	for _, queryFilters := range chunk(allFilters, MaxFiltersPerQuery) {
+		groups := make([]FilterGroup, 0, MaxGroupsPerQuery)
+		for i, filters := range chunk(queryFilters, MaxFiltersPerGroup) {
+			groups = append(groups, FilterGroup{
+				Type:     "count",
+				Value:    GroupValue{Min: 1},
+				Filters:  filters,
+				Disabled: i != 0,
+			})
+		}
+
+		queries = append(queries, Query{
+			Query: QueryBody{
+				Status: Status{Option: "online"},
+				Stats:  groups,
+			},
+			Sort: Sort{Price: "asc"},
+		})
+	}
+
+	return queries, nil
+}
+
+// SearchPath returns the trade search path for a platform and league, defaulting to PC and Standard
+func SearchPath(platform Platform, league string) string {
+	if league == "" {
+		league = "Standard"
+	}
+
+	path := "/trade/search"
+	if realm := platform.Realm(); realm != "" {
+		path += "/" + realm
+	}
+
+	return path + "/" + url.PathEscape(league)
+}
+
+// URL returns a pathofexile.com link that opens the query in the browser
+func (q Query) URL(platform Platform, league string) (string, error) {
+	encoded, err := json.Marshal(q)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	return strings.TrimSuffix(DefaultBaseURL, "/") + SearchPath(platform, league) + "?q=" + url.QueryEscape(string(encoded)), nil
+}
+
+// SearchURLs builds the browser links for every query needed to cover the seeds
+func SearchURLs(jewelType data.JewelType, conqueror data.Conqueror, seeds []uint32, platform Platform, league string) ([]string, error) {
+	queries, err := ConstructQueries(jewelType, conqueror, seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(queries))
+	for _, query := range queries {
+		link, err := query.URL(platform, league)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, link)
+	}
+
+	return urls, nil
+}
+
+func chunk[T any](items []T, size int) [][]T {
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		chunks = append(chunks, items[start:min(start+size, len(items))])
+	}
+
+	return chunks
+}
@@ -0,0 +1,234 @@
+package trade
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FallbackRetryAfter is how long an endpoint is blocked after a 429 response without a Retry-After header
+	FallbackRetryAfter = 10 * time.Second
+	// unknownRulesWindow bounds the request history until the server has reported its rules
+	unknownRulesWindow = time.Minute
+)
+
+type rateRule struct {
+	Hits   int
+	Period time.Duration
+}
+
+type ratePolicy struct {
+	rules        []rateRule
+	history      []time.Time
+	blockedUntil time.Time
+}
+
+// RateLimiter follows the X-Rate-Limit-* headers of the trade API, tracking each endpoint separately
+type RateLimiter struct {
+	mu       sync.Mutex
+	policies map[string]*ratePolicy
+	now      func() time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		policies: make(map[string]*ratePolicy),
+		now:      time.Now,
+	}
+}
+
+func (r *RateLimiter) policy(key string) *ratePolicy {
+	policy, ok := r.policies[key]
+	if !ok {
+		policy = &ratePolicy{}
+		r.policies[key] = policy
+	}
+	return policy
+}
+
+// Delay returns how long to wait before the next request to the endpoint is allowed
+func (r *RateLimiter) Delay(key string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.delay(key)
+}
+
+// delay is Delay with r.mu held
+func (r *RateLimiter) delay(key string) time.Duration {
+	now := r.now()
+	policy := r.policy(key)
+
+	next := policy.blockedUntil
+	for _, rule := range policy.rules {
+		if rule.Hits <= 0 {
+			continue
+		}
+
+		inWindow := 0
+		for _, t := range policy.history {
+			if now.Sub(t) < rule.Period {
+				inWindow++
+			}
+		}
+
+		if inWindow >= rule.Hits {
+			oldest := policy.history[len(policy.history)-rule.Hits]
+			if allowed := oldest.Add(rule.Period); allowed.After(next) {
+				next = allowed
+			}
+		}
+	}
+
+	if next.After(now) {
+		return next.Sub(now)
+	}
+
+	return 0
+}
+
+// Wait blocks until a request to the endpoint is allowed and records it.
+// The check and the record happen under one lock, so concurrent callers never exceed the limit together.
+func (r *RateLimiter) Wait(ctx context.Context, key string) error {
+	for {
+		r.mu.Lock()
+		delay := r.delay(key)
+		if delay <= 0 {
+			r.record(key)
+			r.mu.Unlock()
+			return nil
+		}
+		r.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("rate limit wait cancelled: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// record adds a request to the history of the endpoint with r.mu held
+func (r *RateLimiter) record(key string) {
+	policy := r.policy(key)
+	policy.history = append(policy.history, r.now())
+	r.prune(policy)
+}
+
+// prune drops requests older than the longest known window with r.mu held
+func (r *RateLimiter) prune(policy *ratePolicy) {
+	longest := time.Duration(0)
+	for _, rule := range policy.rules {
+		longest = max(longest, rule.Period)
+	}
+
+	if longest == 0 {
+		longest = unknownRulesWindow
+	}
+
+	cutoff := r.now().Add(-longest)
+	for len(policy.history) > 0 && policy.history[0].Before(cutoff) {
+		policy.history = policy.history[1:]
+	}
+}
+
+// Throttled blocks the endpoint for FallbackRetryAfter after a 429 response,
+// unless the headers passed to Update already blocked it
+func (r *RateLimiter) Throttled(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	policy := r.policy(key)
+	if !policy.blockedUntil.After(now) {
+		policy.blockedUntil = now.Add(FallbackRetryAfter)
+	}
+}
+
+// Update reads the rules and current state the server reported for the endpoint
+func (r *RateLimiter) Update(key string, header http.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	policy := r.policy(key)
+
+	if ruleNames := header.Get("X-Rate-Limit-Rules"); ruleNames != "" {
+		policy.rules = policy.rules[:0]
+
+		for _, name := range strings.Split(ruleNames, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			rules := parseRateTriples(header.Get("X-Rate-Limit-" + name))
+			states := parseRateTriples(header.Get("X-Rate-Limit-" + name + "-State"))
+
+			for i, rule := range rules {
+				policy.rules = append(policy.rules, rateRule{
+					Hits:   rule[0],
+					Period: time.Duration(rule[1]) * time.Second,
+				})
+
+				if i >= len(states) {
+					continue
+				}
+
+				state := states[i]
+				blockedUntil := time.Time{}
+				if state[2] > 0 {
+					blockedUntil = now.Add(time.Duration(state[2]) * time.Second)
+				} else if rule[0] > 0 && state[0] >= rule[0] {
+					blockedUntil = now.Add(time.Duration(rule[1]) * time.Second)
+				}
+
+				if blockedUntil.After(policy.blockedUntil) {
+					policy.blockedUntil = blockedUntil
+				}
+			}
+		}
+	}
+
+	r.prune(policy)
+
+	if retryAfter, err := strconv.Atoi(header.Get("Retry-After")); err == nil && retryAfter > 0 {
+		if blockedUntil := now.Add(time.Duration(retryAfter) * time.Second); blockedUntil.After(policy.blockedUntil) {
+			policy.blockedUntil = blockedUntil
+		}
+	}
+}
+
+// parseRateTriples parses "hits:period:restriction" lists such as "8:10:60,15:60:120"
+func parseRateTriples(value string) [][3]int {
+	triples := make([][3]int, 0)
+	for _, part := range strings.Split(value, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ":")
+		if len(fields) != 3 {
+			continue
+		}
+
+		var triple [3]int
+		valid := true
+		for i, field := range fields {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				valid = false
+				break
+			}
+			triple[i] = n
+		}
+
+		if valid {
+			triples = append(triples, triple)
+		}
+	}
+	return triples
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/trade"
+)
+
+func TestConstructQueries(t *testing.T) {
+	seeds := make([]uint32, 0, 201)
+	for seed := uint32(100); seed <= 300; seed++ {
+		seeds = append(seeds, seed)
+	}
+
+	single, err := trade.ConstructQueries(data.GloriousVanity, data.Xibaqua, seeds)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, single, 2)
+	testza.AssertLen(t, single[0].Query.Stats, trade.MaxGroupsPerQuery)
+	testza.AssertLen(t, single[0].Query.Stats[0].Filters, trade.MaxFiltersPerGroup)
+	testza.AssertFalse(t, single[0].Query.Stats[0].Disabled)
+	testza.AssertTrue(t, single[0].Query.Stats[1].Disabled)
+	testza.AssertLen(t, single[1].Query.Stats, 1)
+	testza.AssertEqual(t, "explicit.pseudo_timeless_jewel_xibaqua", single[1].Query.Stats[0].Filters[0].ID)
+
+	anyConqueror, err := trade.ConstructQueries(data.GloriousVanity, "", seeds)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, anyConqueror, 5)
+	testza.AssertEqual(t, uint32(100), anyConqueror[0].Query.Stats[0].Filters[3].Value.Min)
+
+	_, err = trade.ConstructQueries(data.GloriousVanity, data.Kaom, seeds)
+	testza.AssertNotNil(t, err)
+}
+
+func TestQueryURL(t *testing.T) {
+	queries, err := trade.ConstructQueries(data.LethalPride, data.Kaom, []uint32{12000})
+	testza.AssertNoError(t, err)
+
+	link, err := queries[0].URL(trade.Xbox, "Standard")
+	testza.AssertNoError(t, err)
+	testza.AssertTrue(t, strings.HasPrefix(link, "https://www.pathofexile.com/trade/search/xbox/Standard?q="))
+
+	encoded, err := json.Marshal(queries[0])
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, `{"query":{"status":{"option":"online"},"stats":[{"type":"count","value":{"min":1},"filters":[{"id":"explicit.pseudo_timeless_jewel_kaom","value":{"min":12000,"max":12000}}],"disabled":false}]},"sort":{"price":"asc"}}`, string(encoded))
+}
+
+func TestClientSearchSeeds(t *testing.T) {
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit-Rules", "Ip")
+		w.Header().Set("X-Rate-Limit-Ip", "100:10:60")
+		w.Header().Set("X-Rate-Limit-Ip-State", "1:10:0")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/trade/search/Standard":
+			ids := make([]string, 12)
+			for i := range ids {
+				ids[i] = "listing" + strconv.Itoa(i)
+			}
+			_ = json.NewEncoder(w).Encode(trade.SearchResponse{ID: "abc", Result: ids, Total: len(ids)})
+		case strings.HasPrefix(r.URL.Path, "/api/trade/fetch/"):
+			fetches++
+			testza.AssertEqual(t, "abc", r.URL.Query().Get("query"))
+
+			results := make([]map[string]interface{}, 0)
+			for i, id := range strings.Split(strings.TrimPrefix(r.URL.Path, "/api/trade/fetch/"), ",") {
+				seed := 2000 + i
+				results = append(results, map[string]interface{}{
+					"id": id,
+					"listing": map[string]interface{}{
+						"account": map[string]string{"name": "seller"},
+						"price":   map[string]interface{}{"type": "~price", "amount": 10 - i, "currency": "chaos"},
+					},
+					"item": map[string]interface{}{
+						"name": "Glorious Vanity",
+						"explicitMods": []string{
+							"Bathed in the blood of " + strconv.Itoa(seed) + " sacrificed in the name of Xibaqua",
+							"Passives in radius are Conquered by the Vaal",
+						},
+					},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"result": results})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := trade.NewClient(server.URL)
+	listings, err := client.SearchSeeds(context.Background(), data.GloriousVanity, data.Xibaqua, []uint32{2000, 2001}, trade.PC, "Standard", 0)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 2, fetches)
+	testza.AssertLen(t, listings, 12)
+	testza.AssertEqual(t, uint32(2001), listings[1].Seed)
+	testza.AssertEqual(t, data.Xibaqua, listings[1].Conqueror)
+
+	results := map[uint32]map[uint32]map[uint32]uint32{
+		2000: {1: {25: 8}},
+		2001: {1: {25: 9}},
+	}
+
+	// The same seed listed for another conqueror or jewel must not join onto these results
+	cheaper := []trade.Listing{
+		{Seed: 2000, JewelType: data.GloriousVanity, Conqueror: data.Doryani, Price: &trade.Price{Amount: 1, Currency: "chaos"}},
+		{Seed: 2000, JewelType: data.LethalPride, Conqueror: data.Xibaqua, Price: &trade.Price{Amount: 1, Currency: "chaos"}},
+	}
+
+	ranked := trade.RankByPrice(data.GloriousVanity, data.Xibaqua, results, append(listings, cheaper...), nil)
+	testza.AssertLen(t, ranked, 2)
+	testza.AssertEqual(t, uint32(2001), ranked[0].Seed)
+	testza.AssertEqual(t, float64(9), ranked[0].ChaosValue)
+	testza.AssertEqual(t, float64(10), ranked[1].ChaosValue)
+	testza.AssertLen(t, ranked[1].Listings, 2)
+}
+
+func TestRateLimiterState(t *testing.T) {
+	limiter := trade.NewRateLimiter()
+	testza.AssertEqual(t, time.Duration(0), limiter.Delay("search"))
+
+	header := http.Header{}
+	header.Set("X-Rate-Limit-Rules", "Ip")
+	header.Set("X-Rate-Limit-Ip", "5:10:60")
+	header.Set("X-Rate-Limit-Ip-State", "5:10:0")
+	limiter.Update("search", header)
+	testza.AssertTrue(t, limiter.Delay("search") > 9*time.Second)
+	testza.AssertEqual(t, time.Duration(0), limiter.Delay("fetch"))
+
+	header.Set("X-Rate-Limit-Ip-State", "1:10:120")
+	limiter.Update("fetch", header)
+	testza.AssertTrue(t, limiter.Delay("fetch") > 110*time.Second)
+}
+
+func TestRateLimiterThrottled(t *testing.T) {
+	limiter := trade.NewRateLimiter()
+
+	// A 429 without Retry-After still backs off
+	limiter.Throttled("search")
+	testza.AssertTrue(t, limiter.Delay("search") > trade.FallbackRetryAfter-time.Second)
+
+	header := http.Header{}
+	header.Set("Retry-After", "120")
+	limiter.Update("fetch", header)
+	limiter.Throttled("fetch")
+	testza.AssertTrue(t, limiter.Delay("fetch") > 110*time.Second)
+}
+
+func TestRateLimiterConcurrentWait(t *testing.T) {
+	limiter := trade.NewRateLimiter()
+
+	header := http.Header{}
+	header.Set("X-Rate-Limit-Rules", "Ip")
+	header.Set("X-Rate-Limit-Ip", "3:10:60")
+	header.Set("X-Rate-Limit-Ip-State", "0:10:0")
+	limiter.Update("search", header)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.Wait(ctx, "search") == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	testza.AssertEqual(t, int32(3), allowed.Load())
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+func TestTranslateStatEnglish(t *testing.T) {
+	testza.AssertEqual(t, "12% increased Spell Damage", data.TranslateStat(data.English, 25, 12))
+	testza.AssertEqual(t, "+12 to Strength", data.TranslateStat(data.English, 573, 12))
+	testza.AssertEqual(t, "#% increased Spell Damage", data.TranslateStatTemplate(data.English, 25))
+}
+
+func TestTranslateStatLocalized(t *testing.T) {
+	const lang = data.Language("test-fallback")
+
+	fsys := fstest.MapFS{
+		"stat_descriptions.json": &fstest.MapFile{
+			Data: []byte(`{"descriptors":[{"ids":["spell_damage_+%"],"list":[{"string":"{0}% de dégâts des sorts augmentés","conditions":[{"min":1,"negated":false}]}]}],"includes":[]}`),
+		},
+		"names.json": &fstest.MapFile{
+			Data: []byte(`{"Glorious Vanity":"Vanité glorieuse"}`),
+		},
+	}
+
+	testza.AssertNoError(t, data.LoadTranslationsFS(lang, fsys))
+
+	testza.AssertEqual(t, "12% de dégâts des sorts augmentés", data.TranslateStat(lang, 25, 12))
+	testza.AssertEqual(t, "+12 to Strength", data.TranslateStat(lang, 573, 12))
+	testza.AssertEqual(t, "Vanité glorieuse", data.GloriousVanity.Localize(lang))
+	testza.AssertEqual(t, "Lethal Pride", data.LethalPride.Localize(lang))
+	testza.AssertEqual(t, "Xibaqua", data.Xibaqua.Localize(lang))
+}
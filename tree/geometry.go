@@ -0,0 +1,183 @@
+package tree
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// BaseJewelRadius is the radius of a large timeless jewel in tree coordinates
+const BaseJewelRadius = 1800
+
+type Point struct {
+	X float64
+	Y float64
+}
+
+func (p Point) Distance(other Point) float64 {
+	return math.Hypot(p.X-other.X, p.Y-other.Y)
+}
+
+var (
+	orbit16Angles = []float64{0, 30, 45, 60, 90, 120, 135, 150, 180, 210, 225, 240, 270, 300, 315, 330}
+	orbit40Angles = []float64{
+		0, 10, 20, 30, 40, 45, 50, 60, 70, 80, 90, 100, 110, 120, 130, 135, 140, 150, 160, 170, 180, 190, 200, 210, 220, 225,
+		230, 240, 250, 260, 270, 280, 290, 300, 310, 315, 320, 330, 340, 350,
+	}
+)
+
+func (t *Tree) orbitAngleAt(orbit int64, index int64) float64 {
+	nodesInOrbit := t.dataset.SkillTree.Constants.SkillsPerOrbit[orbit]
+	switch nodesInOrbit {
+	case 16:
+		if index <= 0 || index > 16 {
+			return 0
+		}
+		return orbit16Angles[16-index]
+	case 40:
+		if index <= 0 || index > 40 {
+			return 0
+		}
+		return orbit40Angles[40-index]
+	default:
+		return 360 - (360/float64(nodesInOrbit))*float64(index)
+	}
+}
+
+// GroupCenter returns the center of the group a node belongs to
+func (t *Tree) GroupCenter(node data.Node) (Point, bool) {
+	if node.Group == nil {
+		return Point{}, false
+	}
+
+	group, ok := t.dataset.SkillTree.Groups[strconv.FormatInt(*node.Group, 10)]
+	if !ok {
+		return Point{}, false
+	}
+
+	return Point{X: group.X, Y: group.Y}, true
+}
+
+// OrbitRadius returns the distance of an orbit from its group center
+func (t *Tree) OrbitRadius(orbit int64) float64 {
+	return float64(t.dataset.SkillTree.Constants.OrbitRadii[orbit])
+}
+
+// NodePosition returns the position of a node in tree coordinates
+func (t *Tree) NodePosition(node data.Node) (Point, bool) {
+	if node.Orbit == nil || node.OrbitIndex == nil {
+		return Point{}, false
+	}
+
+	center, ok := t.GroupCenter(node)
+	if !ok {
+		return Point{}, false
+	}
+
+	radius := t.OrbitRadius(*node.Orbit)
+	radians := math.Pi / 180 * t.orbitAngleAt(*node.Orbit, *node.OrbitIndex)
+
+	return Point{
+		X: center.X - math.Sin(radians)*radius,
+		Y: center.Y - math.Cos(radians)*radius,
+	}, true
+}
+
+// isDrawn mirrors the nodes the web UI draws and considers for jewel radii
+func isDrawn(node data.Node) bool {
+	if node.IsProxy != nil && *node.IsProxy {
+		return false
+	}
+
+	if node.ClassStartIndex != nil {
+		return false
+	}
+
+	if node.ExpansionJewel != nil && node.ExpansionJewel.Parent != nil {
+		return false
+	}
+
+	if node.IsBlighted != nil && *node.IsBlighted {
+		return false
+	}
+
+	return node.AscendancyName == nil
+}
+
+// NodesInRadius returns the graph IDs of every drawn node within radius of the socket, sorted
+func (t *Tree) NodesInRadius(socketGraphID uint32, radius float64) []uint32 {
+	socket, ok := t.Node(socketGraphID)
+	if !ok {
+		return []uint32{}
+	}
+
+	center, ok := t.NodePosition(socket)
+	if !ok {
+		return []uint32{}
+	}
+
+	result := make([]uint32, 0)
+	for graphID, position := range t.drawnPositions() {
+		if position.Distance(center) < radius {
+			result = append(result, graphID)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result
+}
+
+// PassivesInRadius returns the passive skills a timeless jewel in the socket can transform
+func (t *Tree) PassivesInRadius(socketGraphID uint32) []*data.PassiveSkill {
+	applicable := make(map[uint32]*data.PassiveSkill)
+	for _, skill := range t.dataset.GetApplicablePassives() {
+		applicable[skill.PassiveSkillGraphID] = skill
+	}
+
+	passives := make([]*data.PassiveSkill, 0)
+	for _, id := range t.NodesInRadius(socketGraphID, BaseJewelRadius) {
+		if skill, ok := applicable[id]; ok {
+			passives = append(passives, skill)
+		}
+	}
+
+	return passives
+}
+
+// JewelSockets returns the graph IDs of the sockets timeless jewels can be placed in
+func (t *Tree) JewelSockets() []uint32 {
+	sockets := make([]uint32, 0, len(t.dataset.SkillTree.JewelSlots))
+	for _, slot := range t.dataset.SkillTree.JewelSlots {
+		node, ok := t.Node(uint32(slot))
+		if !ok || !isDrawn(node) {
+			continue
+		}
+		sockets = append(sockets, uint32(slot))
+	}
+	return sockets
+}
+
+// NodePosition is Tree.NodePosition on the default tree
+func NodePosition(node data.Node) (Point, bool) {
+	return Default().NodePosition(node)
+}
+
+// NodesInRadius is Tree.NodesInRadius on the default tree
+func NodesInRadius(socketGraphID uint32, radius float64) []uint32 {
+	return Default().NodesInRadius(socketGraphID, radius)
+}
+
+// PassivesInRadius is Tree.PassivesInRadius on the default tree
+func PassivesInRadius(socketGraphID uint32) []*data.PassiveSkill {
+	return Default().PassivesInRadius(socketGraphID)
+}
+
+// JewelSockets is Tree.JewelSockets on the default tree
+func JewelSockets() []uint32 {
+	return Default().JewelSockets()
+}
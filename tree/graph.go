@@ -0,0 +1,288 @@
+package tree
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// Graph is the passive tree as an undirected graph of graph IDs, limited to nodes that cost passive points
+type Graph struct {
+	tree        *Tree
+	adjacency   map[uint32][]uint32
+	classStarts map[int]uint32
+}
+
+// isTraversable mirrors the filters of data.GetApplicablePassives, class starts are kept as path sources
+func isTraversable(node data.Node) bool {
+	if node.AscendancyName != nil {
+		return false
+	}
+
+	if node.IsProxy != nil && *node.IsProxy {
+		return false
+	}
+
+	if node.IsBlighted != nil && *node.IsBlighted {
+		return false
+	}
+
+	if node.IsMastery != nil && *node.IsMastery {
+		return false
+	}
+
+	return node.ExpansionJewel == nil || node.ExpansionJewel.Parent == nil
+}
+
+func newGraph(t *Tree) *Graph {
+	skillTree := t.dataset.SkillTree
+	graph := &Graph{
+		tree:        t,
+		adjacency:   make(map[uint32][]uint32),
+		classStarts: make(map[int]uint32),
+	}
+
+	ids := make(map[string]uint32, len(skillTree.Nodes))
+	for key, node := range skillTree.Nodes {
+		id, err := strconv.ParseUint(key, 10, 32)
+		if err != nil || !isTraversable(node) {
+			continue
+		}
+
+		ids[key] = uint32(id)
+		if node.ClassStartIndex != nil {
+			graph.classStarts[int(*node.ClassStartIndex)] = uint32(id)
+		}
+	}
+
+	connected := make(map[[2]uint32]bool)
+	for key, id := range ids {
+		node := skillTree.Nodes[key]
+		for _, neighbours := range [][]string{node.Out, node.In} {
+			for _, neighbourKey := range neighbours {
+				neighbour, ok := ids[neighbourKey]
+				if !ok {
+					continue
+				}
+
+				edge := [2]uint32{min(id, neighbour), max(id, neighbour)}
+				if connected[edge] {
+					continue
+				}
+				connected[edge] = true
+
+				graph.adjacency[id] = append(graph.adjacency[id], neighbour)
+				graph.adjacency[neighbour] = append(graph.adjacency[neighbour], id)
+			}
+		}
+	}
+
+	for id := range graph.adjacency {
+		sort.Slice(graph.adjacency[id], func(i, j int) bool {
+			return graph.adjacency[id][i] < graph.adjacency[id][j]
+		})
+	}
+
+	return graph
+}
+
+// ClassStart returns the starting node of a class
+func (g *Graph) ClassStart(classID int) (uint32, bool) {
+	id, ok := g.classStarts[classID]
+	return id, ok
+}
+
+func (g *Graph) isClassStart(id uint32) bool {
+	for _, start := range g.classStarts {
+		if start == id {
+			return true
+		}
+	}
+	return false
+}
+
+// search runs a breadth first search from the already allocated sources.
+// Every reached node costs one point, other class starts can not be passed through.
+func (g *Graph) search(sources []uint32) (map[uint32]uint32, map[uint32]uint32) {
+	costs := make(map[uint32]uint32, len(g.adjacency))
+	previous := make(map[uint32]uint32)
+
+	queue := make([]uint32, 0, len(sources))
+	for _, source := range sources {
+		if _, ok := costs[source]; ok {
+			continue
+		}
+		costs[source] = 0
+		queue = append(queue, source)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, neighbour := range g.adjacency[current] {
+			if _, ok := costs[neighbour]; ok {
+				continue
+			}
+
+			if g.isClassStart(neighbour) {
+				continue
+			}
+
+			costs[neighbour] = costs[current] + 1
+			previous[neighbour] = current
+			queue = append(queue, neighbour)
+		}
+	}
+
+	return costs, previous
+}
+
+// Distances returns the passive points needed to allocate each reachable node from the sources
+func (g *Graph) Distances(sources []uint32) map[uint32]uint32 {
+	costs, _ := g.search(sources)
+	return costs
+}
+
+// Path returns the nodes to allocate to reach the target from the sources, ending with the target
+func (g *Graph) Path(sources []uint32, target uint32) ([]uint32, bool) {
+	costs, previous := g.search(sources)
+
+	cost, ok := costs[target]
+	if !ok {
+		return nil, false
+	}
+
+	path := make([]uint32, cost)
+	for current, i := target, int(cost)-1; i >= 0; i-- {
+		path[i] = current
+		current = previous[current]
+	}
+
+	return path, true
+}
+
+// SocketPlan holds the cost of reaching a socket and of every node in its radius afterwards
+type SocketPlan struct {
+	Socket     uint32
+	SocketPath []uint32
+	SocketCost uint32
+	// Allocated holds the class start, allocated nodes and socket path
+	Allocated []uint32
+	// NodeCosts holds the extra points to allocate each node in radius once the socket path is taken
+	NodeCosts map[uint32]uint32
+}
+
+// PlanSocket computes the path from the class start and allocated nodes to a socket
+// and the cost of every node in the socket radius from there
+func (g *Graph) PlanSocket(allocated []uint32, classID int, socket uint32) (SocketPlan, bool) {
+	sources := append(make([]uint32, 0, len(allocated)+1), allocated...)
+	if start, ok := g.ClassStart(classID); ok {
+		sources = append(sources, start)
+	}
+
+	socketPath, ok := g.Path(sources, socket)
+	if !ok {
+		return SocketPlan{}, false
+	}
+
+	withSocket := append(append(make([]uint32, 0, len(sources)+len(socketPath)), sources...), socketPath...)
+	costs := g.Distances(withSocket)
+
+	plan := SocketPlan{
+		Socket:     socket,
+		SocketPath: socketPath,
+		SocketCost: uint32(len(socketPath)),
+		Allocated:  withSocket,
+		NodeCosts:  make(map[uint32]uint32),
+	}
+
+	for _, id := range g.tree.NodesInRadius(socket, BaseJewelRadius) {
+		if cost, ok := costs[id]; ok {
+			plan.NodeCosts[id] = cost
+		}
+	}
+
+	return plan, true
+}
+
+// Grow approximates a budgeted Steiner tree over valued nodes.
+// Starting from the allocated nodes it repeatedly takes the cheapest path with the best value per point,
+// counting every valued node along the path, until nothing else fits in the budget.
+// It returns the added nodes in allocation order and the value of every valued node in the final tree.
+func (g *Graph) Grow(allocated []uint32, values map[uint32]float64, budget uint32) ([]uint32, float64) {
+	inTree := make(map[uint32]bool, len(allocated))
+	sources := make([]uint32, 0, len(allocated))
+	for _, id := range allocated {
+		if !inTree[id] {
+			inTree[id] = true
+			sources = append(sources, id)
+		}
+	}
+
+	captured := 0.0
+	for id, value := range values {
+		if inTree[id] {
+			captured += value
+		}
+	}
+
+	targets := make([]uint32, 0, len(values))
+	for id, value := range values {
+		if value > 0 && !inTree[id] {
+			targets = append(targets, id)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i] < targets[j]
+	})
+
+	added := make([]uint32, 0)
+	for {
+		costs, previous := g.search(sources)
+
+		var best []uint32
+		bestGain, bestRatio := 0.0, 0.0
+		for _, target := range targets {
+			cost, ok := costs[target]
+			if !ok || cost == 0 || uint32(len(added))+cost > budget {
+				continue
+			}
+
+			path := make([]uint32, cost)
+			gain := 0.0
+			for current, i := target, int(cost)-1; i >= 0; i-- {
+				path[i] = current
+				gain += values[current]
+				current = previous[current]
+			}
+
+			ratio := gain / float64(cost)
+			if best == nil || ratio > bestRatio || (ratio == bestRatio && len(path) < len(best)) {
+				best, bestGain, bestRatio = path, gain, ratio
+			}
+		}
+
+		if best == nil {
+			break
+		}
+
+		for _, id := range best {
+			inTree[id] = true
+			sources = append(sources, id)
+		}
+		added = append(added, best...)
+		captured += bestGain
+
+		remaining := targets[:0]
+		for _, target := range targets {
+			if !inTree[target] {
+				remaining = append(remaining, target)
+			}
+		}
+		targets = remaining
+	}
+
+	return added, captured
+}
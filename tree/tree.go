@@ -0,0 +1,93 @@
+package tree
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// Tree is the passive tree of one dataset, its node positions and graph are built on first use
+type Tree struct {
+	dataset *data.Dataset
+
+	positionsOnce sync.Once
+	positions     map[uint32]Point
+
+	graphOnce sync.Once
+	graph     *Graph
+}
+
+// New creates the tree of a dataset
+func New(dataset *data.Dataset) *Tree {
+	return &Tree{dataset: dataset}
+}
+
+var defaultTree atomic.Pointer[Tree]
+
+// Default returns the tree of data.Default, it is rebuilt once the default dataset is replaced
+func Default() *Tree {
+	dataset := data.Default()
+	if t := defaultTree.Load(); t != nil && t.dataset == dataset {
+		return t
+	}
+
+	t := New(dataset)
+	defaultTree.Store(t)
+	return t
+}
+
+// Dataset returns the game data the tree is read from
+func (t *Tree) Dataset() *data.Dataset {
+	return t.dataset
+}
+
+// Node returns the skill tree node of a graph ID
+func (t *Tree) Node(graphID uint32) (data.Node, bool) {
+	node, ok := t.dataset.SkillTree.Nodes[strconv.Itoa(int(graphID))]
+	return node, ok
+}
+
+// Graph returns the graph of the tree
+func (t *Tree) Graph() *Graph {
+	t.graphOnce.Do(func() {
+		t.graph = newGraph(t)
+	})
+	return t.graph
+}
+
+// drawnPositions returns the position of every drawn node, keyed by graph ID
+func (t *Tree) drawnPositions() map[uint32]Point {
+	t.positionsOnce.Do(func() {
+		t.positions = make(map[uint32]Point)
+		for id, node := range t.dataset.SkillTree.Nodes {
+			if !isDrawn(node) {
+				continue
+			}
+
+			position, ok := t.NodePosition(node)
+			if !ok {
+				continue
+			}
+
+			graphID, err := strconv.ParseUint(id, 10, 32)
+			if err != nil {
+				continue
+			}
+
+			t.positions[uint32(graphID)] = position
+		}
+	})
+	return t.positions
+}
+
+// Node is Tree.Node on the default tree
+func Node(graphID uint32) (data.Node, bool) {
+	return Default().Node(graphID)
+}
+
+// SkillGraph is Tree.Graph on the default tree
+func SkillGraph() *Graph {
+	return Default().Graph()
+}
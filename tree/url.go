@@ -0,0 +1,225 @@
+package tree
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+)
+
+// ClusterNodeOffset is added to the 16 bit hashes of cluster jewel nodes in tree URLs
+const ClusterNodeOffset = 65536
+
+// CurrentURLVersion is the passive tree URL format written by EncodeURL
+const CurrentURLVersion = 6
+
+var ErrInvalidTreeURL = errors.New("invalid passive tree url")
+
+// Allocation is the set of passives a character has allocated
+type Allocation struct {
+	Version        uint32
+	ClassID        int
+	AscendancyID   int
+	Nodes          []uint32
+	ClusterNodes   []uint32
+	MasteryEffects map[uint32]uint32
+}
+
+// DecodeURL reads a pathofexile.com passive tree link, or just its encoded part
+func DecodeURL(link string) (*Allocation, error) {
+	encoded := strings.TrimSpace(link)
+	if parsed, err := url.Parse(encoded); err == nil && parsed.Path != "" {
+		encoded = parsed.Path
+	}
+
+	encoded = strings.TrimRight(encoded, "/")
+	if i := strings.LastIndex(encoded, "/"); i >= 0 {
+		encoded = encoded[i+1:]
+	}
+
+	encoded = strings.NewReplacer("-", "+", "_", "/").Replace(strings.TrimRight(encoded, "="))
+
+	raw, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTreeURL, err)
+	}
+
+	return Decode(raw)
+}
+
+// Decode reads the binary passive tree format shared by the website and the game
+func Decode(raw []byte) (*Allocation, error) {
+	if len(raw) < 6 {
+		return nil, ErrInvalidTreeURL
+	}
+
+	allocation := &Allocation{
+		Version:        binary.BigEndian.Uint32(raw[0:4]),
+		ClassID:        int(raw[4]),
+		AscendancyID:   int(raw[5]),
+		Nodes:          make([]uint32, 0),
+		ClusterNodes:   make([]uint32, 0),
+		MasteryEffects: make(map[uint32]uint32),
+	}
+
+	if allocation.Version > CurrentURLVersion {
+		return nil, ErrInvalidTreeURL
+	}
+
+	if allocation.Version < 5 {
+		offset := 6
+		if allocation.Version >= 4 {
+			// Skips the fullscreen flag
+			offset = 7
+		}
+
+		if offset > len(raw) {
+			return nil, ErrInvalidTreeURL
+		}
+
+		allocation.Nodes = readHashes(raw[offset:], 0)
+		return allocation, nil
+	}
+
+	reader := byteReader{raw: raw, offset: 6}
+
+	nodes, err := reader.section(2)
+	if err != nil {
+		return nil, err
+	}
+	allocation.Nodes = readHashes(nodes, 0)
+
+	clusters, err := reader.section(2)
+	if err != nil {
+		return nil, err
+	}
+	allocation.ClusterNodes = readHashes(clusters, ClusterNodeOffset)
+
+	// Masteries were added in version 6
+	if allocation.Version < 6 {
+		return allocation, nil
+	}
+
+	masteries, err := reader.section(4)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i+4 <= len(masteries); i += 4 {
+		effect := uint32(binary.BigEndian.Uint16(masteries[i:]))
+		node := uint32(binary.BigEndian.Uint16(masteries[i+2:]))
+		allocation.MasteryEffects[node] = effect
+	}
+
+	return allocation, nil
+}
+
+type byteReader struct {
+	raw    []byte
+	offset int
+}
+
+// section reads a count prefixed list of fixed size entries, missing trailing sections are empty
+func (r *byteReader) section(entrySize int) ([]byte, error) {
+	if r.offset >= len(r.raw) {
+		return nil, nil
+	}
+
+	count := int(r.raw[r.offset])
+	start := r.offset + 1
+	end := start + count*entrySize
+	if end > len(r.raw) {
+		return nil, ErrInvalidTreeURL
+	}
+
+	r.offset = end
+	return r.raw[start:end], nil
+}
+
+func readHashes(raw []byte, offset uint32) []uint32 {
+	hashes := make([]uint32, 0, len(raw)/2)
+	for i := 0; i+2 <= len(raw); i += 2 {
+		hashes = append(hashes, uint32(binary.BigEndian.Uint16(raw[i:]))+offset)
+	}
+	return hashes
+}
+
+// Encode writes the allocation in the current binary format
+func (a *Allocation) Encode() []byte {
+	raw := binary.BigEndian.AppendUint32(nil, CurrentURLVersion)
+	raw = append(raw, byte(a.ClassID), byte(a.AscendancyID))
+
+	raw = append(raw, byte(len(a.Nodes)))
+	for _, node := range a.Nodes {
+		raw = binary.BigEndian.AppendUint16(raw, uint16(node))
+	}
+
+	raw = append(raw, byte(len(a.ClusterNodes)))
+	for _, node := range a.ClusterNodes {
+		raw = binary.BigEndian.AppendUint16(raw, uint16(node-ClusterNodeOffset))
+	}
+
+	masteryNodes := make([]uint32, 0, len(a.MasteryEffects))
+	for node := range a.MasteryEffects {
+		masteryNodes = append(masteryNodes, node)
+	}
+	sort.Slice(masteryNodes, func(i, j int) bool {
+		return masteryNodes[i] < masteryNodes[j]
+	})
+
+	raw = append(raw, byte(len(masteryNodes)))
+	for _, node := range masteryNodes {
+		raw = binary.BigEndian.AppendUint16(raw, uint16(a.MasteryEffects[node]))
+		raw = binary.BigEndian.AppendUint16(raw, uint16(node))
+	}
+
+	return raw
+}
+
+// URL returns a pathofexile.com link to the allocation
+func (a *Allocation) URL() string {
+	return "https://www.pathofexile.com/passive-skill-tree/" + base64.URLEncoding.EncodeToString(a.Encode())
+}
+
+// KnownNodes returns the allocated graph IDs present in the default skill tree
+func (a *Allocation) KnownNodes() []uint32 {
+	known := make([]uint32, 0, len(a.Nodes))
+	for _, id := range a.Nodes {
+		if _, ok := Node(id); ok {
+			known = append(known, id)
+		}
+	}
+	return known
+}
+
+// PassiveIDs maps the allocated graph IDs to passive skill indices
+func (a *Allocation) PassiveIDs() []uint32 {
+	ids := make([]uint32, 0, len(a.Nodes))
+	for _, id := range a.KnownNodes() {
+		if skill := data.GetPassiveSkillByGraphID(id); skill != nil {
+			ids = append(ids, skill.Index)
+		}
+	}
+	return ids
+}
+
+// RestrictPassives keeps only the passive skill indices that are allocated
+func (a *Allocation) RestrictPassives(passiveIDs []uint32) []uint32 {
+	allocated := make(map[uint32]bool)
+	for _, id := range a.PassiveIDs() {
+		allocated[id] = true
+	}
+
+	restricted := make([]uint32, 0, len(passiveIDs))
+	for _, id := range passiveIDs {
+		if allocated[id] {
+			restricted = append(restricted, id)
+		}
+	}
+	return restricted
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/BlazesRus/timeless-jewels/data"
+	"github.com/BlazesRus/timeless-jewels/tree"
+)
+
+func TestDecodeTreeURL(t *testing.T) {
+	allocation := &tree.Allocation{
+		ClassID:        5,
+		AscendancyID:   1,
+		Nodes:          []uint32{61525, 63965, 26725},
+		ClusterNodes:   []uint32{65536 + 12},
+		MasteryEffects: map[uint32]uint32{53188: 64875},
+	}
+
+	decoded, err := tree.DecodeURL(allocation.URL() + "?accountName=test&characterName=test")
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, uint32(tree.CurrentURLVersion), decoded.Version)
+	testza.AssertEqual(t, 5, decoded.ClassID)
+	testza.AssertEqual(t, 1, decoded.AscendancyID)
+	testza.AssertEqual(t, allocation.Nodes, decoded.Nodes)
+	testza.AssertEqual(t, allocation.ClusterNodes, decoded.ClusterNodes)
+	testza.AssertEqual(t, allocation.MasteryEffects, decoded.MasteryEffects)
+
+	passives := decoded.PassiveIDs()
+	testza.AssertLen(t, passives, 3)
+	testza.AssertEqual(t, []uint32{passives[2]}, decoded.RestrictPassives([]uint32{passives[2], 2286}))
+	testza.AssertEqual(t, uint32(26725), data.GetPassiveSkillByIndex(passives[2]).PassiveSkillGraphID)
+}
+
+func TestDecodeLegacyTreeURL(t *testing.T) {
+	raw := []byte{0, 0, 0, 4, 3, 0, 1, 0xF0, 0x55, 0x68, 0x65}
+
+	decoded, err := tree.DecodeURL("https://www.pathofexile.com/passive-skill-tree/3.10.0/" + base64.URLEncoding.EncodeToString(raw))
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, uint32(4), decoded.Version)
+	testza.AssertEqual(t, []uint32{61525, 26725}, decoded.Nodes)
+
+	// Version 5 has node and cluster sections but no masteries
+	raw = []byte{0, 0, 0, 5, 3, 0, 2, 0xF0, 0x55, 0x68, 0x65, 1, 0x00, 0x01, 1, 0x00, 0x02}
+	decoded, err = tree.DecodeURL("https://www.pathofexile.com/passive-skill-tree/3.16.0/" + base64.URLEncoding.EncodeToString(raw))
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, uint32(5), decoded.Version)
+	testza.AssertEqual(t, []uint32{61525, 26725}, decoded.Nodes)
+	testza.AssertEqual(t, []uint32{tree.ClusterNodeOffset + 1}, decoded.ClusterNodes)
+	testza.AssertLen(t, decoded.MasteryEffects, 0)
+
+	raw = []byte{0, 0, 0, tree.CurrentURLVersion + 1, 3, 0, 0, 0, 0}
+	_, err = tree.DecodeURL("https://www.pathofexile.com/passive-skill-tree/" + base64.URLEncoding.EncodeToString(raw))
+	testza.AssertErrorIs(t, err, tree.ErrInvalidTreeURL)
+
+	_, err = tree.DecodeURL("https://www.pathofexile.com/passive-skill-tree/AAAA")
+	testza.AssertErrorIs(t, err, tree.ErrInvalidTreeURL)
+}
+
+func TestPlanSocket(t *testing.T) {
+	graph := tree.SkillGraph()
+
+	start, ok := graph.ClassStart(1)
+	testza.AssertTrue(t, ok)
+
+	plan, ok := graph.PlanSocket(nil, 1, 26725)
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, uint32(9), plan.SocketCost)
+	testza.AssertLen(t, plan.SocketPath, 9)
+	testza.AssertEqual(t, uint32(26725), plan.SocketPath[8])
+	testza.AssertNotContains(t, plan.SocketPath, start)
+
+	halfway, ok := graph.PlanSocket(plan.SocketPath[:4], 1, 26725)
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, uint32(5), halfway.SocketCost)
+	testza.AssertEqual(t, plan.NodeCosts, halfway.NodeCosts)
+
+	for _, id := range tree.NodesInRadius(26725, tree.BaseJewelRadius) {
+		if cost, ok := plan.NodeCosts[id]; ok {
+			onPath := false
+			for _, pathID := range plan.SocketPath {
+				onPath = onPath || pathID == id
+			}
+			testza.AssertEqual(t, onPath, cost == 0)
+		}
+	}
+}
+
+func TestTreePerDataset(t *testing.T) {
+	dataset, err := data.NewDataset(data.EmbeddedSources())
+	testza.AssertNoError(t, err)
+
+	other := tree.New(dataset)
+	testza.AssertTrue(t, tree.Default().Dataset() == data.Default())
+	testza.AssertEqual(t, tree.NodesInRadius(testSocket, tree.BaseJewelRadius), other.NodesInRadius(testSocket, tree.BaseJewelRadius))
+	testza.AssertFalse(t, tree.SkillGraph() == other.Graph())
+
+	// Geometry is read from the dataset of the tree, not the default one
+	removed := tree.NodesInRadius(testSocket, tree.BaseJewelRadius)[0]
+	changed, err := data.NewDataset(data.EmbeddedSources())
+	testza.AssertNoError(t, err)
+	delete(changed.SkillTree.Nodes, strconv.Itoa(int(removed)))
+
+	testza.AssertNotContains(t, tree.New(changed).NodesInRadius(testSocket, tree.BaseJewelRadius), removed)
+	testza.AssertContains(t, tree.NodesInRadius(testSocket, tree.BaseJewelRadius), removed)
+}
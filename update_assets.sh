@@ -21,4 +21,12 @@ curl -L "https://go-pob-data.pages.dev/data/$2/stat_translations/en/stat_descrip
 curl -L "https://go-pob-data.pages.dev/data/$2/stat_translations/en/passive_skill_stat_descriptions.json.gz" > ./data/passive_skill_stat_descriptions.json.gz
 curl -L "https://go-pob-data.pages.dev/data/$2/stat_translations/en/passive_skill_aura_stat_descriptions.json.gz" > ./data/passive_skill_aura_stat_descriptions.json.gz
 
+# Extra languages are not embedded, they are loaded at runtime with data.LoadTranslationsFromDir
+for lang in ${EXTRA_LANGUAGES}; do
+    mkdir -p "./data/translations/$lang"
+    for file in stat_descriptions passive_skill_stat_descriptions passive_skill_aura_stat_descriptions; do
+        curl -L "https://go-pob-data.pages.dev/data/$2/stat_translations/$lang/$file.json.gz" > "./data/translations/$lang/$file.json.gz"
+    done
+done
+
 go generate -tags tools -x ./...
\ No newline at end of file
@@ -15,6 +15,14 @@ func Expose() *crystalline.Exposer {
 	e.ExposeFuncOrPanic(data.GetAlternatePassiveSkillByIndex)
 	e.ExposeFuncOrPanic(data.GetAlternatePassiveAdditionByIndex)
 	e.ExposeFuncOrPanic(data.GetPassiveSkillByIndex)
+	e.ExposeFuncOrPanic(data.SearchStats)
+	e.ExposeFuncOrPanic(calculator.PlanAllocation)
+	e.ExposeFuncOrPanic(calculator.CompareSocket)
+	e.ExposeFuncOrPanic(calculator.SummarizeDevotion)
+	e.ExposeFuncOrPanic(calculator.SearchDevotion)
+	e.ExposeFuncOrPanic(calculator.FilterPassiveTypes)
+	e.ExposeFuncOrPanic(calculator.CompareSeeds)
+	e.ExposeFuncOrPanic(calculator.SearchAllJewels)
 
 	e.ExposeOrPanic(map[data.JewelType]string{
 		data.GloriousVanity:  data.GloriousVanity.String(),
@@ -24,6 +32,13 @@ func Expose() *crystalline.Exposer {
 		data.ElegantHubris:   data.ElegantHubris.String(),
 	}, "data", "TimelessJewels")
 
+	e.ExposeOrPanic(map[data.PassiveSkillType]string{
+		data.SmallAttribute: "SmallAttribute",
+		data.SmallNormal:    "SmallNormal",
+		data.Notable:        "Notable",
+		data.KeyStone:       "KeyStone",
+	}, "data", "PassiveSkillTypes")
+
 	e.ExposeOrPanic(data.TimelessJewelConquerors, "data", "TimelessJewelConquerors")
 	e.ExposeOrPanic(data.TimelessJewelSeedRanges, "data", "TimelessJewelSeedRanges")
 	e.ExposeOrPanic(data.GetApplicablePassives(), "data", "PassiveSkills")